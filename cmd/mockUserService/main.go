@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"go-zero-template/internal/mock/userservice"
+)
+
+var (
+	fixtureFile = flag.String("f", "etc/mockUserService.yaml", "the fixture file (yaml or json)")
+	addr        = flag.String("addr", "localhost:8000", "the listen address")
+)
+
+// 本地开发用的模拟 user_service，默认监听 Services.user_service 的默认地址
+func main() {
+	flag.Parse()
+	fixture, err := userservice.LoadFixture(*fixtureFile)
+	if err != nil {
+		log.Fatalf("加载 fixture 失败: %v", err)
+	}
+
+	srv := userservice.New(fixture)
+	fmt.Printf("Starting mock user_service at %s...\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, srv.Handler()))
+}
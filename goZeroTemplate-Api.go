@@ -10,7 +10,9 @@ import (
 
 	"go-zero-template/internal/config"
 	"go-zero-template/internal/handler"
+	"go-zero-template/internal/response"
 	"go-zero-template/internal/svc"
+	"go-zero-template/internal/task"
 
 	"github.com/joho/godotenv"
 	"github.com/zeromicro/go-zero/core/conf"
@@ -28,12 +30,25 @@ func main() {
 	}
 	var c config.Config
 	conf.MustLoad(*configFile, &c)
+	if err := c.Services.ApplyEnv(); err != nil {
+		log.Fatalf("加载下游服务配置失败: %v", err)
+	}
+	response.SetStatusMode(c.Response.StatusMode, c.Response.DefaultStatus, c.Response.CodeStatusMap())
+
+	// 先创建 ServiceContext，使 defer 按相反顺序执行：
+	// 先停止 HTTP 服务（不再接收新请求），再停止调度器并关闭各项资源
+	ctx := svc.NewServiceContext(c)
+	defer ctx.Close()
 
 	server := rest.MustNewServer(c.RestConf)
 	defer server.Stop()
 
-	ctx := svc.NewServiceContext(c)
 	handler.RegisterHandlers(server, ctx)
+	task.RegisterTasks(ctx.Scheduler, ctx)
+	task.RegisterWorkflows(ctx.Workflow)
+	// 处理器和工作流全部注册后再启动调度器，避免启动时的同步和补偿扫描遇到未注册的处理器
+	// 不能放到 svc.NewServiceContext 中：task 包依赖 svc，在 svc 中注册处理器会导致循环引用
+	ctx.Scheduler.Start()
 
 	fmt.Printf("Starting server at %s:%d...\n", c.Host, c.Port)
 	server.Start()
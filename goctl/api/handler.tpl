@@ -0,0 +1,31 @@
+package {{.PkgName}}
+
+import (
+	"net/http"
+
+	{{if .HasRequest}}"github.com/zeromicro/go-zero/rest/httpx"
+	{{end}}res "go-zero-template/internal/response"
+
+	{{.ImportPackages}}
+)
+
+{{if .HasDoc}}{{.Doc}}{{end}}
+func {{.HandlerName}}(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		{{if .HasRequest}}var req types.{{.RequestType}}
+		if err := httpx.Parse(r, &req); err != nil {
+			res.Response(w, nil, res.NewParseError(err))
+			return
+		}{{end}}
+
+		defer func() {
+			if err := recover(); err != nil {
+				res.Response(w, nil, res.InternalServerError)
+			}
+		}()
+
+		l := {{.LogicName}}.New{{.LogicType}}(r.Context(), svcCtx)
+		{{if .HasResp}}resp, {{end}}err := l.{{.Call}}({{if .HasRequest}}&req{{end}})
+		res.Response(w, {{if .HasResp}}resp{{else}}nil{{end}}, err)
+	}
+}
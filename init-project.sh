@@ -66,6 +66,7 @@ echo "-------------------------------------------"
 MODULE_NAME_FILES=(
     "go.mod"
     "${OLD_SERVICE_FILE}.go"
+    "goctl/api/handler.tpl"
     "internal/middleware/authMiddleware.go"
     "internal/svc/redis.go"
     "internal/svc/serviceContext.go"
@@ -77,6 +78,96 @@ MODULE_NAME_FILES=(
     "internal/logic/system/healthLogic.go"
     "internal/request/user.go"
     "internal/request/request.go"
+    "internal/db/cron_task.go"
+    "internal/scheduler/scheduler.go"
+    "internal/task/tasks.go"
+    "internal/task/system/healthCheckTask.go"
+    "internal/db/cron_job_run.go"
+    "internal/handler/run/getCronJobRunHandler.go"
+    "internal/handler/run/listCronJobRunsHandler.go"
+    "internal/logic/run/convert.go"
+    "internal/logic/run/getCronJobRunLogic.go"
+    "internal/logic/run/helper.go"
+    "internal/logic/run/listCronJobRunsLogic.go"
+    "internal/handler/crontask/createCronTaskHandler.go"
+    "internal/handler/crontask/deleteCronTaskHandler.go"
+    "internal/handler/crontask/getCronTaskHandler.go"
+    "internal/handler/crontask/listCronTasksHandler.go"
+    "internal/handler/crontask/pauseCronTaskHandler.go"
+    "internal/handler/crontask/resumeCronTaskHandler.go"
+    "internal/handler/crontask/runCronTaskHandler.go"
+    "internal/handler/crontask/updateCronTaskHandler.go"
+    "internal/logic/crontask/convert.go"
+    "internal/logic/crontask/createCronTaskLogic.go"
+    "internal/logic/crontask/deleteCronTaskLogic.go"
+    "internal/logic/crontask/getCronTaskLogic.go"
+    "internal/logic/crontask/helper.go"
+    "internal/logic/crontask/listCronTasksLogic.go"
+    "internal/logic/crontask/pauseCronTaskLogic.go"
+    "internal/logic/crontask/resumeCronTaskLogic.go"
+    "internal/logic/crontask/runCronTaskLogic.go"
+    "internal/logic/crontask/updateCronTaskLogic.go"
+    "internal/scheduler/run.go"
+    "internal/scheduler/sync.go"
+    "internal/handler/run/redriveCronJobRunHandler.go"
+    "internal/logic/run/redriveCronJobRunLogic.go"
+    "internal/scheduler/retry.go"
+    "internal/scheduler/catchup.go"
+    "internal/scheduler/concurrency.go"
+    "internal/db/workflow_node_run.go"
+    "internal/db/workflow_run.go"
+    "internal/handler/workflow/getWorkflowRunHandler.go"
+    "internal/handler/workflow/listWorkflowRunsHandler.go"
+    "internal/handler/workflow/listWorkflowsHandler.go"
+    "internal/logic/workflow/convert.go"
+    "internal/logic/workflow/getWorkflowRunLogic.go"
+    "internal/logic/workflow/helper.go"
+    "internal/logic/workflow/listWorkflowRunsLogic.go"
+    "internal/logic/workflow/listWorkflowsLogic.go"
+    "internal/task/workflows.go"
+    "internal/workflow/runner.go"
+    "internal/db/user_sync_item.go"
+    "internal/db/user_sync_run.go"
+    "internal/handler/usersync/getUserSyncRunHandler.go"
+    "internal/handler/usersync/listUserSyncItemsHandler.go"
+    "internal/handler/usersync/listUserSyncRunsHandler.go"
+    "internal/logic/usersync/convert.go"
+    "internal/logic/usersync/getUserSyncRunLogic.go"
+    "internal/logic/usersync/helper.go"
+    "internal/logic/usersync/listUserSyncItemsLogic.go"
+    "internal/logic/usersync/listUserSyncRunsLogic.go"
+    "internal/task/user/userSyncTask.go"
+    "internal/usersync/diff.go"
+    "internal/usersync/source.go"
+    "internal/usersync/syncer.go"
+    "internal/handler/auth/invalidateUserProfileCacheHandler.go"
+    "internal/logic/auth/invalidateUserProfileCacheLogic.go"
+    "internal/middleware/profileCache.go"
+    "internal/middleware/roleGuardMiddleware.go"
+    "internal/db/org_scope.go"
+    "internal/request/token_provider.go"
+    "internal/request/resilience.go"
+    "internal/request/do.go"
+    "internal/request/registry.go"
+    "internal/request/tls.go"
+    "cmd/mockUserService/main.go"
+    "internal/mock/userservice/fixture.go"
+    "internal/mock/userservice/handlers.go"
+    "internal/mock/userservice/server.go"
+    "internal/request/metrics.go"
+    "internal/scheduler/run_test.go"
+    "internal/scheduler/scheduler_test.go"
+    "internal/request/tls_test.go"
+    "internal/request/user_test.go"
+    "internal/usersync/syncer_test.go"
+    "internal/request/tracing_test.go"
+    "internal/db/cron_job_run_test.go"
+    "internal/logic/crontask/helper_test.go"
+    "internal/workflow/runner_test.go"
+    "internal/usersync/source_test.go"
+    "internal/middleware/profileCache_test.go"
+    "internal/db/org_scope_test.go"
+    "internal/request/resilience_test.go"
 )
 
 # 需要替换 API 服务名称的文件列表
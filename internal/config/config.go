@@ -1,31 +1,82 @@
 package config
 
 import (
+	"time"
+
 	"github.com/zeromicro/go-zero/rest"
 )
 
 type Config struct {
 	rest.RestConf
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	Auth     AuthConfig
-	Services ServicesConfig
+	Postgres  PostgresConfig
+	Redis     RedisConfig
+	Auth      AuthConfig
+	Services  ServicesConfig
+	Scheduler SchedulerConfig
+	UserSync  UserSyncConfig
+	Response  ResponseConfig
 }
 
 type AuthConfig struct {
-	AccessSecret string `json:",env=AUTH_ACCESS_SECRET"`
+	AccessSecret     string        `json:",env=AUTH_ACCESS_SECRET"`
+	ProfileCacheTTL  time.Duration `json:",default=5m,env=AUTH_PROFILE_CACHE_TTL"`   // 用户信息缓存时长，角色变更最长在该时长后生效（可调用清除缓存接口立即生效）
+	NegativeCacheTTL time.Duration `json:",default=30s,env=AUTH_NEGATIVE_CACHE_TTL"` // 被 user_service 拒绝的 token 的缓存时长
 }
 
-type ServicesConfig struct {
-	UserService BaseServiceConfig
-}
+// UserServiceName user_service 在 ServicesConfig 中的服务名
+const UserServiceName = "user_service"
+
+// UserSyncSourceName 上游员工数据源在 ServicesConfig 中的服务名，服务的基础地址即员工数据接口地址
+// 使用独立的客户端，超时、重试、熔断、并发限制和认证与 user_service 隔离
+const UserSyncSourceName = "user_sync_source"
 
+// ServicesConfig 下游服务注册表，key 为服务名（如 user_service）
+// 每个服务的配置项可被 <服务名大写>_<配置项> 环境变量覆盖，如 USER_SERVICE_HOST、USER_SERVICE_MAX_RETRIES，见 ApplyEnv
+type ServicesConfig map[string]BaseServiceConfig
+
+// 下游服务的认证方式
+const (
+	AuthModeNone  = "none"  // 不携带 Authorization
+	AuthModeToken = "token" // 携带调用方传入的 token，或 request.SystemAuth 表示使用系统账号登录获取的 token
+)
+
+// BaseServiceConfig 单个下游服务的配置
 type BaseServiceConfig struct {
-	Host               string `json:",env=USER_SERVICE_HOST"`
-	Port               int    `json:",env=USER_SERVICE_PORT"`
-	Path               string `json:",env=USER_SERVICE_PATH"`
-	SuperAdminUsername string `json:",env=USER_SERVICE_SUPER_ADMIN_USERNAME"`
-	SuperAdminPassword string `json:",env=USER_SERVICE_SUPER_ADMIN_PASSWORD"`
+	Scheme   string        `json:",default=http,options=http|https"` // 协议
+	Host     string        // 主机名
+	Port     int           // 端口
+	Path     string        `json:",optional"`                         // 接口基础路径，如 /api/v1/user
+	Timeout  time.Duration `json:",default=30s"`                      // 单次请求超时时间（含重试时为每次尝试），context 截止时间更早时以 context 为准
+	TLS      TLSConfig     `json:",optional"`                         // TLS 配置，Scheme 为 https 时生效
+	AuthMode string        `json:",default=token,options=none|token"` // 认证方式: none-不携带 Authorization, token-携带调用方 token 或系统账号 token
+	// 系统账号（超级管理员）用户名和密码，AuthMode 为 token 时用于 request.SystemAuth
+	SuperAdminUsername string `json:",optional"`
+	SuperAdminPassword string `json:",optional"`
+	// 系统账号 token 距过期不足该时长时重新登录
+	TokenRefreshBefore time.Duration `json:",default=1m"`
+	// 系统账号 token 没有 exp 声明时的缓存时长
+	TokenTTL time.Duration `json:",default=30m"`
+	// 幂等请求（GET/PUT/DELETE 等）在网络错误或 429/502/503/504 时的最大重试次数，0 表示不重试
+	MaxRetries      int           `json:",default=2"`
+	RetryBackoff    time.Duration `json:",default=100ms"` // 首次重试前的等待时间，之后每次翻倍
+	RetryBackoffMax time.Duration `json:",default=1s"`    // 单次重试等待时间上限
+	// 本服务的最大并发请求数，超出时立即返回 503，0 表示不限制
+	MaxConcurrency int `json:",default=100"`
+}
+
+// TLSConfig 下游服务的 TLS 配置，文件均为 PEM 格式
+type TLSConfig struct {
+	ServerName         string `json:",optional"` // 校验服务端证书使用的主机名，为空时使用 Host
+	CAFile             string `json:",optional"` // 校验服务端证书的 CA 证书，为空时使用系统根证书
+	CertFile           string `json:",optional"` // 客户端证书（mTLS），需与 KeyFile 同时配置
+	KeyFile            string `json:",optional"` // 客户端私钥（mTLS）
+	InsecureSkipVerify bool   `json:",optional"` // 跳过服务端证书校验，仅限本地开发
+}
+
+// UserSyncConfig 上游员工同步到 user_service 的配置
+// 上游接口的地址、超时、重试和认证在 Services 的 user_sync_source 中配置，接口返回 BaseResponse，data.list 为员工列表
+type UserSyncConfig struct {
+	SourceName string `json:",default=upstream,env=USER_SYNC_SOURCE_NAME"` // 上游数据源名称，记录在同步运行记录中
 }
 
 type PostgresConfig struct {
@@ -43,3 +94,39 @@ type RedisConfig struct {
 	Password string `json:",optional,env=REDIS_PASSWORD"`
 	DB       int    `json:",default=0,env=REDIS_DB"`
 }
+
+type SchedulerConfig struct {
+	Enabled        bool          `json:",default=true,env=SCHEDULER_ENABLED"`
+	Location       string        `json:",default=Asia/Shanghai,env=SCHEDULER_LOCATION"`
+	LeaderElection bool          `json:",default=true,env=SCHEDULER_LEADER_ELECTION"` // 多副本部署时仅 leader 执行任务
+	LeaseTTL       time.Duration `json:",default=15s,env=SCHEDULER_LEASE_TTL"`        // leader 租约时长，leader 宕机后最长 LeaseTTL 内完成切换
+	ReloadInterval time.Duration `json:",default=30s,env=SCHEDULER_RELOAD_INTERVAL"`  // 定期重新加载任务定义的间隔，兜底丢失的变更通知
+	// 计划触发时间超过该时长仍无运行记录视为错过，需大于 cron 触发到写入运行记录的延迟
+	MisfireThreshold time.Duration `json:",default=1m,env=SCHEDULER_MISFIRE_THRESHOLD"`
+	MaxCatchup       int           `json:",default=10,env=SCHEDULER_MAX_CATCHUP"` // fire_all 策略未指定上限时最多补执行的次数
+	// 停机时等待任务结束的时间，超时后取消任务 context；应小于 RestConf.Shutdown.WaitTime（默认 5.5s）
+	ShutdownTimeout time.Duration `json:",default=2s,env=SCHEDULER_SHUTDOWN_TIMEOUT"`
+}
+
+// ResponseConfig 接口响应配置
+type ResponseConfig struct {
+	// HTTP 状态码模式：always_ok 始终返回 200；http 按错误 code 返回真实状态码，响应体不变
+	StatusMode    string       `json:",default=always_ok,options=always_ok|http,env=RESPONSE_STATUS_MODE"`
+	DefaultStatus int          `json:",default=500,env=RESPONSE_DEFAULT_STATUS"` // http 模式下未映射且不是 4xx/5xx 状态码的 code 对应的状态码
+	CodeStatus    []CodeStatus `json:",optional"`                                // http 模式下自定义 code 对应的状态码，ParseError(10005) 默认为 400
+}
+
+// CodeStatus 自定义错误 code 与 HTTP 状态码的映射
+type CodeStatus struct {
+	Code   int // 错误 code
+	Status int // HTTP 状态码
+}
+
+// CodeStatusMap 转为 code -> HTTP 状态码
+func (c ResponseConfig) CodeStatusMap() map[int]int {
+	m := make(map[int]int, len(c.CodeStatus))
+	for _, cs := range c.CodeStatus {
+		m[cs.Code] = cs.Status
+	}
+	return m
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ApplyEnv 使用环境变量覆盖各下游服务的配置，变量名为 <服务名大写>_<配置项>
+// 配置项由字段名转为大写下划线形式，嵌套结构体逐级拼接，如:
+//
+//	user_service.Host                -> USER_SERVICE_HOST
+//	user_service.SuperAdminUsername  -> USER_SERVICE_SUPER_ADMIN_USERNAME
+//	user_service.TLS.ServerName      -> USER_SERVICE_TLS_SERVER_NAME
+//
+// 只能覆盖配置文件中已声明的服务；覆盖后重新校验 Scheme、AuthMode 和 TLS 证书配置
+func (s ServicesConfig) ApplyEnv() error {
+	for name, service := range s {
+		prefix := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+		if err := applyEnv(reflect.ValueOf(&service).Elem(), prefix); err != nil {
+			return fmt.Errorf("服务 %s: %w", name, err)
+		}
+		if err := service.validate(); err != nil {
+			return fmt.Errorf("服务 %s: %w", name, err)
+		}
+		s[name] = service
+	}
+	return nil
+}
+
+func (c BaseServiceConfig) validate() error {
+	if c.Scheme != "http" && c.Scheme != "https" {
+		return fmt.Errorf("Scheme 只能为 http 或 https: %s", c.Scheme)
+	}
+	if c.AuthMode != AuthModeNone && c.AuthMode != AuthModeToken {
+		return fmt.Errorf("AuthMode 只能为 %s 或 %s: %s", AuthModeNone, AuthModeToken, c.AuthMode)
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return fmt.Errorf("TLS.CertFile 和 TLS.KeyFile 需同时配置")
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func applyEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		key := prefix + "_" + envName(t.Field(i).Name)
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field, key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("环境变量 %s 格式错误: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case field.CanInt():
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case field.CanFloat():
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("不支持的类型 %s", field.Type())
+	}
+	return nil
+}
+
+// envName 将字段名转为大写下划线形式，连续大写视为缩写: SuperAdminUsername -> SUPER_ADMIN_USERNAME, CAFile -> CA_FILE
+func envName(field string) string {
+	runes := []rune(field)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
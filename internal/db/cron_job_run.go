@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go-zero-template/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CronJobRunRepository struct {
+	db *gorm.DB
+}
+
+func NewCronJobRunRepository(db *gorm.DB) *CronJobRunRepository {
+	return &CronJobRunRepository{db: db}
+}
+
+// CronJobRunFilter 运行记录查询条件，零值字段不参与过滤
+type CronJobRunFilter struct {
+	TaskID    int64
+	TaskName  string
+	Trigger   string
+	Status    string
+	StartFrom *time.Time
+	StartTo   *time.Time
+}
+
+// Create 创建运行记录
+func (r *CronJobRunRepository) Create(ctx context.Context, run *models.CronJobRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// Finish 更新运行记录的结束状态，返回是否更新成功
+// FencingToken 不为 0 的运行（leader 执行的计划和补偿运行）仅当不小于任务记录的 fencing token 时更新，
+// 已有更新的 leader 运行过该任务时返回 false，拒绝过期 leader 的写入
+func (r *CronJobRunRepository) Finish(ctx context.Context, run *models.CronJobRun) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&models.CronJobRun{}).Where("id = ?", run.ID)
+	if run.FencingToken > 0 {
+		query = query.Where("fencing_token >= (SELECT COALESCE(MAX(t.fencing_token), 0) FROM cron_tasks t WHERE t.id = ?)", run.TaskID)
+	}
+	result := query.Updates(map[string]any{
+		"status":      run.Status,
+		"attempts":    run.Attempts,
+		"finished_at": run.FinishedAt,
+		"duration_ms": run.DurationMs,
+		"error":       run.Error,
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetByID 按 ID 查询运行记录，不存在返回 nil, nil
+func (r *CronJobRunRepository) GetByID(ctx context.Context, id int64) (*models.CronJobRun, error) {
+	return FirstOrNil[models.CronJobRun](r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// List 分页查询运行记录，按开始时间倒序
+func (r *CronJobRunRepository) List(ctx context.Context, filter CronJobRunFilter, page, pageSize int) ([]models.CronJobRun, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.CronJobRun{})
+	if filter.TaskID != 0 {
+		query = query.Where("task_id = ?", filter.TaskID)
+	}
+	if filter.TaskName != "" {
+		query = query.Where("task_name = ?", filter.TaskName)
+	}
+	if filter.Trigger != "" {
+		query = query.Where("trigger = ?", filter.Trigger)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.StartFrom != nil {
+		query = query.Where("started_at >= ?", *filter.StartFrom)
+	}
+	if filter.StartTo != nil {
+		query = query.Where("started_at < ?", *filter.StartTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []models.CronJobRun
+	err := query.Order("started_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&runs).Error
+	return runs, total, err
+}
+
+// LastScheduledAt 查询任务最近一次按计划（含补偿）运行的计划触发时间，没有记录时返回 nil
+// 早期记录没有 scheduled_at，以 started_at 代替
+func (r *CronJobRunRepository) LastScheduledAt(ctx context.Context, taskID int64, triggers []string) (*time.Time, error) {
+	var last sql.NullTime
+	err := r.db.WithContext(ctx).Model(&models.CronJobRun{}).
+		Select("MAX(COALESCE(scheduled_at, started_at))").
+		Where("task_id = ? AND trigger IN ?", taskID, triggers).
+		Row().Scan(&last)
+	if err != nil || !last.Valid {
+		return nil, err
+	}
+	return &last.Time, nil
+}
+
+// MarkRedriven 将死信运行标记为已重新投递，仅当记录仍为死信状态时生效，返回是否标记成功
+func (r *CronJobRunRepository) MarkRedriven(ctx context.Context, id int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.CronJobRun{}).
+		Where("id = ? AND status = ?", id, models.RunStatusDeadLetter).
+		Update("status", models.RunStatusRedriven)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RestoreDeadLetter 重新投递未能执行时，将已标记为重新投递的运行恢复为死信状态
+func (r *CronJobRunRepository) RestoreDeadLetter(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Model(&models.CronJobRun{}).
+		Where("id = ? AND status = ?", id, models.RunStatusRedriven).
+		Update("status", models.RunStatusDeadLetter).Error
+}
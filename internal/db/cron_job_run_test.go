@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/lock"
+	"go-zero-template/internal/models"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// fenceTables 模拟 cron_tasks、cron_job_runs 的 fencing_token 列，按 Fence、Finish 生成的 SQL 更新
+// SQL 中没有 fencing 条件时无条件更新，用于验证过期 leader 的写入被拒绝
+type fenceTables struct {
+	t     *testing.T
+	tasks map[int64]int64 // 任务ID -> fencing_token
+	runs  map[int64]int64 // 运行记录ID -> fencing_token
+}
+
+func (f *fenceTables) exec(query string, args []any) int64 {
+	switch {
+	case strings.HasPrefix(query, `UPDATE "cron_tasks"`):
+		// SET fencing_token = $1 WHERE id = $2 [AND fencing_token <= $3]
+		token, id := args[0].(int64), args[1].(int64)
+		if _, ok := f.tasks[id]; !ok {
+			return 0
+		}
+		if strings.Contains(query, "fencing_token <= ") && f.tasks[id] > token {
+			return 0
+		}
+		f.tasks[id] = token
+		return 1
+	case strings.HasPrefix(query, `UPDATE "cron_job_runs"`):
+		// SET ... WHERE id = $6 [AND fencing_token >= (SELECT ... WHERE t.id = $7)]
+		if !strings.Contains(query, "FROM cron_tasks t WHERE t.id = ") {
+			return 1
+		}
+		id, taskID := args[len(args)-2].(int64), args[len(args)-1].(int64)
+		if f.runs[id] < f.tasks[taskID] {
+			return 0
+		}
+		return 1
+	}
+	f.t.Fatalf("未预期的 SQL: %s", query)
+	return 0
+}
+
+func TestFencingRejectsStaleLeader(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := context.Background()
+
+	tables := &fenceTables{t: t, tasks: map[int64]int64{1: 0}, runs: map[int64]int64{}}
+	gdb, _ := newTestDB(t, tables.exec)
+	repo := NewRepository(gdb)
+
+	// 旧 leader 获得租约并开始运行
+	old, err := lock.NewLocker(client, "a").Acquire(ctx, "leader", time.Second)
+	if err != nil {
+		t.Fatalf("旧 leader Acquire: %v", err)
+	}
+	if ok, err := repo.CronTask.Fence(ctx, 1, old.Token()); err != nil || !ok {
+		t.Fatalf("旧 leader Fence = %v, %v, want true", ok, err)
+	}
+	oldRun := &models.CronJobRun{ID: 10, TaskID: 1, FencingToken: old.Token(), Status: models.RunStatusSuccess}
+	tables.runs[oldRun.ID] = oldRun.FencingToken
+
+	// 旧 leader 暂停超过 TTL，新 leader 获得租约并运行同一任务
+	mr.FastForward(time.Second)
+	newer, err := lock.NewLocker(client, "b").Acquire(ctx, "leader", time.Second)
+	if err != nil {
+		t.Fatalf("新 leader Acquire: %v", err)
+	}
+	if newer.Token() <= old.Token() {
+		t.Fatalf("新 leader token = %d, want > %d", newer.Token(), old.Token())
+	}
+	if ok, err := repo.CronTask.Fence(ctx, 1, newer.Token()); err != nil || !ok {
+		t.Fatalf("新 leader Fence = %v, %v, want true", ok, err)
+	}
+	newRun := &models.CronJobRun{ID: 11, TaskID: 1, FencingToken: newer.Token(), Status: models.RunStatusSuccess}
+	tables.runs[newRun.ID] = newRun.FencingToken
+
+	// 旧 leader 恢复后的写入被拒绝
+	if ok, err := repo.CronJobRun.Finish(ctx, oldRun); err != nil || ok {
+		t.Fatalf("旧 leader Finish = %v, %v, want false", ok, err)
+	}
+	if ok, err := repo.CronTask.Fence(ctx, 1, old.Token()); err != nil || ok {
+		t.Fatalf("旧 leader 再次 Fence = %v, %v, want false", ok, err)
+	}
+	if got := tables.tasks[1]; got != newer.Token() {
+		t.Fatalf("任务 fencing_token = %d, want %d", got, newer.Token())
+	}
+
+	// 新 leader 的写入和不携带 token 的手动运行不受影响
+	if ok, err := repo.CronJobRun.Finish(ctx, newRun); err != nil || !ok {
+		t.Fatalf("新 leader Finish = %v, %v, want true", ok, err)
+	}
+	manual := &models.CronJobRun{ID: 12, TaskID: 1, Status: models.RunStatusSuccess}
+	if ok, err := repo.CronJobRun.Finish(ctx, manual); err != nil || !ok {
+		t.Fatalf("手动运行 Finish = %v, %v, want true", ok, err)
+	}
+}
+
+func TestCronJobRunListFilter(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	tests := []struct {
+		name      string
+		filter    CronJobRunFilter
+		page      int
+		pageSize  int
+		wantWhere string
+		wantPage  string
+	}{
+		{"无过滤条件", CronJobRunFilter{}, 1, 20, "", "LIMIT 20"},
+		{"按任务", CronJobRunFilter{TaskID: 1, TaskName: "sync"}, 2, 10, ` WHERE task_id = 1 AND task_name = 'sync'`, "LIMIT 10 OFFSET 10"},
+		{"按触发方式和状态", CronJobRunFilter{Trigger: "manual", Status: "dead_letter"}, 1, 20, ` WHERE trigger = 'manual' AND status = 'dead_letter'`, "LIMIT 20"},
+		{"时间范围左闭右开", CronJobRunFilter{StartFrom: &from, StartTo: &to}, 3, 5,
+			` WHERE started_at >= '2026-01-01 00:00:00' AND started_at < '2026-01-02 00:00:00'`, "LIMIT 5 OFFSET 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := newTestDB(t, nil)
+			if _, _, err := NewCronJobRunRepository(db).List(context.Background(), tt.filter, tt.page, tt.pageSize); err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			want := []string{
+				`SELECT count(*) FROM "cron_job_runs"` + tt.wantWhere,
+				`SELECT * FROM "cron_job_runs"` + tt.wantWhere + ` ORDER BY started_at DESC, id DESC ` + tt.wantPage,
+			}
+			if len(recorder.sqls) != len(want) {
+				t.Fatalf("SQL = %q, want %q", recorder.sqls, want)
+			}
+			for i := range want {
+				if recorder.sqls[i] != want[i] {
+					t.Fatalf("SQL[%d] = %s\nwant %s", i, recorder.sqls[i], want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+
+	"go-zero-template/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CronTaskRepository struct {
+	db *gorm.DB
+}
+
+func NewCronTaskRepository(db *gorm.DB) *CronTaskRepository {
+	return &CronTaskRepository{db: db}
+}
+
+// GetByID 按 ID 查询任务，不存在返回 nil, nil
+func (r *CronTaskRepository) GetByID(ctx context.Context, id int64) (*models.CronTask, error) {
+	return FirstOrNil[models.CronTask](r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// GetByName 按名称查询任务，不存在返回 nil, nil
+func (r *CronTaskRepository) GetByName(ctx context.Context, name string) (*models.CronTask, error) {
+	return TakeOrNil[models.CronTask](r.db.WithContext(ctx).Where("name = ?", name))
+}
+
+// ListEnabled 查询所有已启用的任务
+func (r *CronTaskRepository) ListEnabled(ctx context.Context) ([]models.CronTask, error) {
+	var tasks []models.CronTask
+	err := r.db.WithContext(ctx).Where("enabled = ?", true).Order("id").Find(&tasks).Error
+	return tasks, err
+}
+
+// CronTaskFilter 任务查询条件，零值字段不参与过滤
+type CronTaskFilter struct {
+	Name    string
+	Handler string
+	Enabled *bool
+}
+
+// List 分页查询任务，按 ID 正序
+func (r *CronTaskRepository) List(ctx context.Context, filter CronTaskFilter, page, pageSize int) ([]models.CronTask, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.CronTask{})
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+	if filter.Handler != "" {
+		query = query.Where("handler = ?", filter.Handler)
+	}
+	if filter.Enabled != nil {
+		query = query.Where("enabled = ?", *filter.Enabled)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var tasks []models.CronTask
+	err := query.Order("id").Offset((page - 1) * pageSize).Limit(pageSize).Find(&tasks).Error
+	return tasks, total, err
+}
+
+// Create 创建任务
+func (r *CronTaskRepository) Create(ctx context.Context, task *models.CronTask) error {
+	return r.db.WithContext(ctx).Create(task).Error
+}
+
+// Update 按字段更新任务，updates 的 key 为列名
+func (r *CronTaskRepository) Update(ctx context.Context, id int64, updates map[string]any) error {
+	return r.db.WithContext(ctx).Model(&models.CronTask{ID: id}).Updates(updates).Error
+}
+
+// Fence 记录执行任务的 leader fencing token，仅当 token 不小于已记录的值时生效，返回是否记录成功
+// 返回 false 表示已有更新的 leader 运行过该任务（或任务已删除），本实例的 leader 身份已过期
+func (r *CronTaskRepository) Fence(ctx context.Context, id, token int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.CronTask{}).
+		Where("id = ? AND fencing_token <= ?", id, token).
+		UpdateColumn("fencing_token", token)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Delete 删除任务（运行记录保留）
+func (r *CronTaskRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&models.CronTask{}, id).Error
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var errNotSupported = errors.New("testConn: 不支持")
+
+// testConn 不连接数据库的 database/sql 连接：Exec 的影响行数由 exec 决定，Query 返回空结果集
+type testConn struct {
+	exec func(query string, args []any) int64
+}
+
+func (c testConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c testConn) Driver() driver.Driver                        { return c }
+func (c testConn) Open(string) (driver.Conn, error)             { return c, nil }
+func (c testConn) Prepare(string) (driver.Stmt, error)          { return nil, errNotSupported }
+func (c testConn) Begin() (driver.Tx, error)                    { return nil, errNotSupported }
+func (c testConn) Close() error                                 { return nil }
+
+func (c testConn) ExecContext(_ context.Context, query string, named []driver.NamedValue) (driver.Result, error) {
+	if c.exec == nil {
+		return driver.RowsAffected(0), nil
+	}
+	args := make([]any, len(named))
+	for i, v := range named {
+		args[i] = v.Value
+	}
+	return driver.RowsAffected(c.exec(query, args)), nil
+}
+
+func (c testConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+// sqlRecorder 记录 gorm 执行的 SQL（参数已内联）
+type sqlRecorder struct {
+	logger.Interface
+	sqls []string
+}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.sqls = append(r.sqls, sql)
+}
+
+// newTestDB 基于 testConn 的 postgres gorm.DB，返回的 sqlRecorder 记录执行过的 SQL
+func newTestDB(t *testing.T, exec func(query string, args []any) int64) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+	conn := sql.OpenDB(testConn{exec: exec})
+	t.Cleanup(func() { _ = conn.Close() })
+	recorder := &sqlRecorder{Interface: logger.Discard}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 recorder,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	return db, recorder
+}
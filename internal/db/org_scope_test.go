@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/types"
+	"go-zero-template/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestOrgScope(t *testing.T) {
+	id := func(n int) *int { return &n }
+	user := func(role utils.RoleType, levels ...*int) *types.User {
+		u := &types.User{}
+		u.RoleCode = string(role)
+		ptrs := []**int{
+			&u.OrgLevel1ID, &u.OrgLevel2ID, &u.OrgLevel3ID, &u.OrgLevel4ID, &u.OrgLevel5ID,
+			&u.OrgLevel6ID, &u.OrgLevel7ID, &u.OrgLevel8ID, &u.OrgLevel9ID,
+		}
+		for i, level := range levels {
+			*ptrs[i] = level
+		}
+		return u
+	}
+
+	const deny = ` WHERE 1 = 0`
+	tests := []struct {
+		name      string
+		user      *types.User
+		table     string
+		wantWhere string
+	}{
+		{"用户为空", nil, "", deny},
+		{"超级管理员不过滤", user(utils.RoleSuperAdmin), "", ""},
+		{"管理员按一级组织", user(utils.RoleAdmin, id(1), id(2), id(3)), "", ` WHERE org_level1_id = 1`},
+		{"超级审核员按一级组织", user(utils.RoleSuperReviewer, id(1), id(2)), "", ` WHERE org_level1_id = 1`},
+		{"区域管理员按二级组织", user(utils.RoleRegionManager, id(1), id(2), id(3)), "", ` WHERE org_level1_id = 1 AND org_level2_id = 2`},
+		{"区域管理员二级组织为空", user(utils.RoleRegionManager, id(1)), "", deny},
+		{"管理员没有组织信息", user(utils.RoleAdmin), "", deny},
+		{"操作员按最深一级组织", user(utils.RoleOperator, id(1), id(2), id(3)), "",
+			` WHERE org_level1_id = 1 AND org_level2_id = 2 AND org_level3_id = 3`},
+		{"操作员中间层级为空", user(utils.RoleOperator, id(1), nil, id(3)), "", deny},
+		{"操作员没有组织信息", user(utils.RoleOperator), "", deny},
+		{"未知角色按自己所在组织", user("guest", id(1)), "", ` WHERE org_level1_id = 1`},
+		{"联表查询指定表名", user(utils.RoleRegionManager, id(1), id(2)), "records",
+			` WHERE records.org_level1_id = 1 AND records.org_level2_id = 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := newTestDB(t, nil)
+			stmt := db.Session(&gorm.Session{DryRun: true}).Table("records").
+				Scopes(OrgScope(tt.user, tt.table)).Find(&[]map[string]any{}).Statement
+			got := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+			if want := `SELECT * FROM "records"` + tt.wantWhere; got != want {
+				t.Fatalf("SQL = %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestOrgScopeFromContextWithoutAuth(t *testing.T) {
+	scope, err := OrgScopeFromContext(context.Background(), "")
+	var respErr *response.Error
+	if scope != nil || !errors.As(err, &respErr) || respErr.Code != http.StatusUnauthorized {
+		t.Fatalf("OrgScopeFromContext = %v, %v, want 401", scope != nil, err)
+	}
+}
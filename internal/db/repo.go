@@ -3,8 +3,21 @@ package db
 import "gorm.io/gorm"
 
 type Repository struct {
+	CronTask        *CronTaskRepository
+	CronJobRun      *CronJobRunRepository
+	WorkflowRun     *WorkflowRunRepository
+	WorkflowNodeRun *WorkflowNodeRunRepository
+	UserSyncRun     *UserSyncRunRepository
+	UserSyncItem    *UserSyncItemRepository
 }
 
 func NewRepository(db *gorm.DB) *Repository {
-	return &Repository{}
+	return &Repository{
+		CronTask:        NewCronTaskRepository(db),
+		CronJobRun:      NewCronJobRunRepository(db),
+		WorkflowRun:     NewWorkflowRunRepository(db),
+		WorkflowNodeRun: NewWorkflowNodeRunRepository(db),
+		UserSyncRun:     NewUserSyncRunRepository(db),
+		UserSyncItem:    NewUserSyncItemRepository(db),
+	}
 }
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+
+	"go-zero-template/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// userSyncItemBatchSize 批量写入明细时每批的条数
+const userSyncItemBatchSize = 500
+
+type UserSyncItemRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSyncItemRepository(db *gorm.DB) *UserSyncItemRepository {
+	return &UserSyncItemRepository{db: db}
+}
+
+// CreateBatch 批量创建用户同步明细
+func (r *UserSyncItemRepository) CreateBatch(ctx context.Context, items []*models.UserSyncItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(items, userSyncItemBatchSize).Error
+}
+
+// ListByRun 分页查询同步运行的明细，action 为空时不过滤，按 ID 正序
+func (r *UserSyncItemRepository) ListByRun(ctx context.Context, runID int64, action string, page, pageSize int) ([]models.UserSyncItem, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.UserSyncItem{}).Where("run_id = ?", runID)
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []models.UserSyncItem
+	err := query.Order("id").Offset((page - 1) * pageSize).Limit(pageSize).Find(&items).Error
+	return items, total, err
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+
+	"go-zero-template/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type UserSyncRunRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSyncRunRepository(db *gorm.DB) *UserSyncRunRepository {
+	return &UserSyncRunRepository{db: db}
+}
+
+// UserSyncRunFilter 用户同步运行记录查询条件，零值字段不参与过滤
+type UserSyncRunFilter struct {
+	Source string
+	Status string
+	DryRun *bool
+}
+
+// Create 创建用户同步运行记录
+func (r *UserSyncRunRepository) Create(ctx context.Context, run *models.UserSyncRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// Finish 更新用户同步运行记录的结束状态与数量
+func (r *UserSyncRunRepository) Finish(ctx context.Context, run *models.UserSyncRun) error {
+	return r.db.WithContext(ctx).Model(&models.UserSyncRun{}).Where("id = ?", run.ID).Updates(map[string]any{
+		"status":      run.Status,
+		"total":       run.Total,
+		"created":     run.Created,
+		"updated":     run.Updated,
+		"skipped":     run.Skipped,
+		"failed":      run.Failed,
+		"finished_at": run.FinishedAt,
+		"duration_ms": run.DurationMs,
+		"error":       run.Error,
+	}).Error
+}
+
+// GetByID 按 ID 查询用户同步运行记录，不存在返回 nil, nil
+func (r *UserSyncRunRepository) GetByID(ctx context.Context, id int64) (*models.UserSyncRun, error) {
+	return FirstOrNil[models.UserSyncRun](r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// List 分页查询用户同步运行记录，按开始时间倒序
+func (r *UserSyncRunRepository) List(ctx context.Context, filter UserSyncRunFilter, page, pageSize int) ([]models.UserSyncRun, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.UserSyncRun{})
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.DryRun != nil {
+		query = query.Where("dry_run = ?", *filter.DryRun)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []models.UserSyncRun
+	err := query.Order("started_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&runs).Error
+	return runs, total, err
+}
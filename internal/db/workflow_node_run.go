@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+
+	"go-zero-template/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type WorkflowNodeRunRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkflowNodeRunRepository(db *gorm.DB) *WorkflowNodeRunRepository {
+	return &WorkflowNodeRunRepository{db: db}
+}
+
+// CreateBatch 批量创建节点运行记录
+func (r *WorkflowNodeRunRepository) CreateBatch(ctx context.Context, runs []*models.WorkflowNodeRun) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(runs).Error
+}
+
+// Update 更新节点运行记录的状态、时间和错误信息
+func (r *WorkflowNodeRunRepository) Update(ctx context.Context, run *models.WorkflowNodeRun) error {
+	return r.db.WithContext(ctx).Model(&models.WorkflowNodeRun{}).Where("id = ?", run.ID).Updates(map[string]any{
+		"status":      run.Status,
+		"started_at":  run.StartedAt,
+		"finished_at": run.FinishedAt,
+		"duration_ms": run.DurationMs,
+		"error":       run.Error,
+	}).Error
+}
+
+// ListByWorkflowRun 查询工作流运行的所有节点运行记录，按创建顺序
+func (r *WorkflowNodeRunRepository) ListByWorkflowRun(ctx context.Context, workflowRunID int64) ([]models.WorkflowNodeRun, error) {
+	var runs []models.WorkflowNodeRun
+	err := r.db.WithContext(ctx).Where("workflow_run_id = ?", workflowRunID).Order("id").Find(&runs).Error
+	return runs, err
+}
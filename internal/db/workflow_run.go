@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"go-zero-template/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type WorkflowRunRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkflowRunRepository(db *gorm.DB) *WorkflowRunRepository {
+	return &WorkflowRunRepository{db: db}
+}
+
+// WorkflowRunFilter 工作流运行记录查询条件，零值字段不参与过滤
+type WorkflowRunFilter struct {
+	Workflow  string
+	Status    string
+	StartFrom *time.Time
+	StartTo   *time.Time
+}
+
+// Create 创建工作流运行记录
+func (r *WorkflowRunRepository) Create(ctx context.Context, run *models.WorkflowRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// Finish 更新工作流运行记录的结束状态
+func (r *WorkflowRunRepository) Finish(ctx context.Context, run *models.WorkflowRun) error {
+	return r.db.WithContext(ctx).Model(&models.WorkflowRun{}).Where("id = ?", run.ID).Updates(map[string]any{
+		"status":      run.Status,
+		"finished_at": run.FinishedAt,
+		"duration_ms": run.DurationMs,
+		"error":       run.Error,
+	}).Error
+}
+
+// GetByID 按 ID 查询工作流运行记录，不存在返回 nil, nil
+func (r *WorkflowRunRepository) GetByID(ctx context.Context, id int64) (*models.WorkflowRun, error) {
+	return FirstOrNil[models.WorkflowRun](r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// List 分页查询工作流运行记录，按开始时间倒序
+func (r *WorkflowRunRepository) List(ctx context.Context, filter WorkflowRunFilter, page, pageSize int) ([]models.WorkflowRun, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.WorkflowRun{})
+	if filter.Workflow != "" {
+		query = query.Where("workflow = ?", filter.Workflow)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.StartFrom != nil {
+		query = query.Where("started_at >= ?", *filter.StartFrom)
+	}
+	if filter.StartTo != nil {
+		query = query.Where("started_at < ?", *filter.StartTo)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []models.WorkflowRun
+	err := query.Order("started_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&runs).Error
+	return runs, total, err
+}
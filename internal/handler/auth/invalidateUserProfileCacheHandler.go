@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	res "go-zero-template/internal/response"
+
+	"go-zero-template/internal/logic/auth"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+)
+
+// 清除用户认证缓存
+func InvalidateUserProfileCacheHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.InvalidateUserProfileCacheRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			res.Response(w, nil, res.NewParseError(err))
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				res.Response(w, nil, res.InternalServerError)
+			}
+		}()
+
+		l := auth.NewInvalidateUserProfileCacheLogic(r.Context(), svcCtx)
+		resp, err := l.InvalidateUserProfileCache(&req)
+		res.Response(w, resp, err)
+	}
+}
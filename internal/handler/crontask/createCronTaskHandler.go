@@ -0,0 +1,33 @@
+package crontask
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	res "go-zero-template/internal/response"
+
+	"go-zero-template/internal/logic/crontask"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+)
+
+// 创建定时任务
+func CreateCronTaskHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CreateCronTaskRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			res.Response(w, nil, res.NewParseError(err))
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				res.Response(w, nil, res.InternalServerError)
+			}
+		}()
+
+		l := crontask.NewCreateCronTaskLogic(r.Context(), svcCtx)
+		resp, err := l.CreateCronTask(&req)
+		res.Response(w, resp, err)
+	}
+}
@@ -0,0 +1,33 @@
+package crontask
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	res "go-zero-template/internal/response"
+
+	"go-zero-template/internal/logic/crontask"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+)
+
+// 查询任务详情
+func GetCronTaskHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.GetCronTaskRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			res.Response(w, nil, res.NewParseError(err))
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				res.Response(w, nil, res.InternalServerError)
+			}
+		}()
+
+		l := crontask.NewGetCronTaskLogic(r.Context(), svcCtx)
+		resp, err := l.GetCronTask(&req)
+		res.Response(w, resp, err)
+	}
+}
@@ -17,7 +17,7 @@ func PingUserServiceHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		var req types.PingUserServiceRequest
 		if err := httpx.Parse(r, &req); err != nil {
 			// 输出详细的解析错误信息
-			res.Response(w, nil, err)
+			res.Response(w, nil, res.NewParseError(err))
 			return
 		}
 
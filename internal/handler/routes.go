@@ -6,8 +6,13 @@ package handler
 import (
 	"net/http"
 
+	auth "go-zero-template/internal/handler/auth"
+	crontask "go-zero-template/internal/handler/crontask"
 	ping "go-zero-template/internal/handler/ping"
+	run "go-zero-template/internal/handler/run"
 	system "go-zero-template/internal/handler/system"
+	usersync "go-zero-template/internal/handler/usersync"
+	workflow "go-zero-template/internal/handler/workflow"
 	"go-zero-template/internal/svc"
 
 	"github.com/zeromicro/go-zero/rest"
@@ -29,6 +34,159 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 		rest.WithPrefix("/api/v1/cron/ping"),
 	)
 
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AuthMiddleware, serverCtx.AdminGuard},
+			[]rest.Route{
+				{
+					// 查询运行记录列表
+					Method:  http.MethodGet,
+					Path:    "/",
+					Handler: run.ListCronJobRunsHandler(serverCtx),
+				},
+				{
+					// 查询运行记录详情
+					Method:  http.MethodGet,
+					Path:    "/:id",
+					Handler: run.GetCronJobRunHandler(serverCtx),
+				},
+				{
+					// 重新投递死信运行
+					Method:  http.MethodPost,
+					Path:    "/:id/redrive",
+					Handler: run.RedriveCronJobRunHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/cron/runs"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AuthMiddleware, serverCtx.AdminGuard},
+			[]rest.Route{
+				{
+					// 查询任务列表
+					Method:  http.MethodGet,
+					Path:    "/",
+					Handler: crontask.ListCronTasksHandler(serverCtx),
+				},
+				{
+					// 查询任务详情
+					Method:  http.MethodGet,
+					Path:    "/:id",
+					Handler: crontask.GetCronTaskHandler(serverCtx),
+				},
+				{
+					// 创建定时任务
+					Method:  http.MethodPost,
+					Path:    "/",
+					Handler: crontask.CreateCronTaskHandler(serverCtx),
+				},
+				{
+					// 更新定时任务
+					Method:  http.MethodPut,
+					Path:    "/:id",
+					Handler: crontask.UpdateCronTaskHandler(serverCtx),
+				},
+				{
+					// 暂停定时任务
+					Method:  http.MethodPost,
+					Path:    "/:id/pause",
+					Handler: crontask.PauseCronTaskHandler(serverCtx),
+				},
+				{
+					// 恢复定时任务
+					Method:  http.MethodPost,
+					Path:    "/:id/resume",
+					Handler: crontask.ResumeCronTaskHandler(serverCtx),
+				},
+				{
+					// 删除定时任务
+					Method:  http.MethodDelete,
+					Path:    "/:id",
+					Handler: crontask.DeleteCronTaskHandler(serverCtx),
+				},
+				{
+					// 立即执行任务
+					Method:  http.MethodPost,
+					Path:    "/:id/run",
+					Handler: crontask.RunCronTaskHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/cron/tasks"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AuthMiddleware, serverCtx.AdminGuard},
+			[]rest.Route{
+				{
+					// 查询工作流列表
+					Method:  http.MethodGet,
+					Path:    "/",
+					Handler: workflow.ListWorkflowsHandler(serverCtx),
+				},
+				{
+					// 查询工作流运行记录列表
+					Method:  http.MethodGet,
+					Path:    "/runs",
+					Handler: workflow.ListWorkflowRunsHandler(serverCtx),
+				},
+				{
+					// 查询工作流运行记录详情
+					Method:  http.MethodGet,
+					Path:    "/runs/:id",
+					Handler: workflow.GetWorkflowRunHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/cron/workflows"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AuthMiddleware, serverCtx.AdminGuard},
+			[]rest.Route{
+				{
+					// 查询用户同步运行记录列表
+					Method:  http.MethodGet,
+					Path:    "/",
+					Handler: usersync.ListUserSyncRunsHandler(serverCtx),
+				},
+				{
+					// 查询用户同步运行记录详情
+					Method:  http.MethodGet,
+					Path:    "/:id",
+					Handler: usersync.GetUserSyncRunHandler(serverCtx),
+				},
+				{
+					// 查询用户同步差异明细
+					Method:  http.MethodGet,
+					Path:    "/:id/items",
+					Handler: usersync.ListUserSyncItemsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/cron/user-sync/runs"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AuthMiddleware, serverCtx.AdminGuard},
+			[]rest.Route{
+				{
+					// 清除用户认证缓存
+					Method:  http.MethodDelete,
+					Path:    "/users/:user_id/profile-cache",
+					Handler: auth.InvalidateUserProfileCacheHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1/cron/auth"),
+	)
+
 	server.AddRoutes(
 		[]rest.Route{
 			{
@@ -0,0 +1,33 @@
+package run
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	res "go-zero-template/internal/response"
+
+	"go-zero-template/internal/logic/run"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+)
+
+// 查询运行记录列表
+func ListCronJobRunsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ListCronJobRunsRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			res.Response(w, nil, res.NewParseError(err))
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				res.Response(w, nil, res.InternalServerError)
+			}
+		}()
+
+		l := run.NewListCronJobRunsLogic(r.Context(), svcCtx)
+		resp, err := l.ListCronJobRuns(&req)
+		res.Response(w, resp, err)
+	}
+}
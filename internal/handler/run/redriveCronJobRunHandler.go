@@ -0,0 +1,33 @@
+package run
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	res "go-zero-template/internal/response"
+
+	"go-zero-template/internal/logic/run"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+)
+
+// 重新投递死信运行
+func RedriveCronJobRunHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.RedriveCronJobRunRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			res.Response(w, nil, res.NewParseError(err))
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				res.Response(w, nil, res.InternalServerError)
+			}
+		}()
+
+		l := run.NewRedriveCronJobRunLogic(r.Context(), svcCtx)
+		resp, err := l.RedriveCronJobRun(&req)
+		res.Response(w, resp, err)
+	}
+}
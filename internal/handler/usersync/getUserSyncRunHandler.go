@@ -0,0 +1,33 @@
+package usersync
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	res "go-zero-template/internal/response"
+
+	"go-zero-template/internal/logic/usersync"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+)
+
+// 查询用户同步运行记录详情
+func GetUserSyncRunHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.GetUserSyncRunRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			res.Response(w, nil, res.NewParseError(err))
+			return
+		}
+
+		defer func() {
+			if err := recover(); err != nil {
+				res.Response(w, nil, res.InternalServerError)
+			}
+		}()
+
+		l := usersync.NewGetUserSyncRunLogic(r.Context(), svcCtx)
+		resp, err := l.GetUserSyncRun(&req)
+		res.Response(w, resp, err)
+	}
+}
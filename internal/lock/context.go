@@ -0,0 +1,16 @@
+package lock
+
+import "context"
+
+type tokenKey struct{}
+
+// WithToken 将 fencing token 存入 context，供任务处理器写入业务数据时校验（参考 CronTaskRepository.Fence）
+func WithToken(ctx context.Context, token int64) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// TokenFromContext 从 context 中获取 fencing token
+func TokenFromContext(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(tokenKey{}).(int64)
+	return token, ok
+}
@@ -0,0 +1,161 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Elector 基于租约的单主选举：同一时刻只有一个实例为 leader，leader 宕机后租约过期由其他实例接管
+type Elector struct {
+	locker *Locker
+	key    string
+	ttl    time.Duration
+
+	mu        sync.RWMutex
+	lease     *Lease
+	expiresAt time.Time
+
+	// startOnce 同时用于 Stop：未启动时 Stop 占用它，之后的 Start 不再生效
+	startOnce sync.Once
+	started   bool
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewElector(locker *Locker, key string, ttl time.Duration) *Elector {
+	return &Elector{
+		locker: locker,
+		key:    key,
+		ttl:    ttl,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start 启动选举循环（可重复调用，Stop 之后调用不生效）
+// 首次竞选同步完成，返回后即可通过 IsLeader 判断本实例是否为 leader
+func (e *Elector) Start() {
+	e.startOnce.Do(func() {
+		e.started = true
+		e.tick()
+		go e.loop()
+	})
+}
+
+// Stop 停止选举并主动释放租约，便于其他实例立即接管（可重复调用，未启动时不做任何操作）
+func (e *Elector) Stop() {
+	e.stopOnce.Do(e.shutdown)
+}
+
+func (e *Elector) shutdown() {
+	e.startOnce.Do(func() {})
+	if !e.started {
+		return
+	}
+	close(e.stop)
+	<-e.done
+
+	e.mu.Lock()
+	lease := e.lease
+	e.lease = nil
+	e.mu.Unlock()
+
+	if lease != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := lease.Release(ctx); err != nil {
+			logx.Errorf("释放 leader 租约失败: key=%s, err=%v", e.key, err)
+		}
+	}
+}
+
+// IsLeader 当前实例是否为 leader（本地租约已过期时视为非 leader）
+func (e *Elector) IsLeader() bool {
+	_, ok := e.Token()
+	return ok
+}
+
+// Token 当前 leader 任期的 fencing token
+func (e *Elector) Token() (int64, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.lease == nil || time.Now().After(e.expiresAt) {
+		return 0, false
+	}
+	return e.lease.Token(), true
+}
+
+func (e *Elector) loop() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			e.tick()
+		}
+	}
+}
+
+func (e *Elector) tick() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+	defer cancel()
+
+	e.mu.RLock()
+	lease := e.lease
+	e.mu.RUnlock()
+
+	// 从发出请求前开始计算本地过期时间，Redis 中的 key 不会早于它过期
+	start := time.Now()
+	if lease == nil {
+		acquired, err := e.locker.Acquire(ctx, e.key, e.ttl)
+		if err != nil {
+			if !errors.Is(err, ErrNotAcquired) {
+				logx.Errorf("竞选 leader 失败: key=%s, err=%v", e.key, err)
+			}
+			return
+		}
+		e.mu.Lock()
+		e.lease = acquired
+		e.expiresAt = e.localExpiry(start)
+		e.mu.Unlock()
+		logx.Infof("成为 leader: key=%s, owner=%s, token=%d", e.key, e.locker.Owner(), acquired.Token())
+		return
+	}
+
+	err := lease.Renew(ctx)
+	switch {
+	case err == nil:
+		e.mu.Lock()
+		e.expiresAt = e.localExpiry(start)
+		e.mu.Unlock()
+	case errors.Is(err, ErrLeaseLost):
+		e.mu.Lock()
+		e.lease = nil
+		e.mu.Unlock()
+		logx.Infof("失去 leader 身份: key=%s, owner=%s", e.key, e.locker.Owner())
+	default:
+		// Redis 暂时不可用：保留租约直到本地过期，期间 IsLeader 会自动返回 false
+		logx.Errorf("续期 leader 租约失败: key=%s, err=%v", e.key, err)
+		e.mu.Lock()
+		if time.Now().After(e.expiresAt) {
+			e.lease = nil
+		}
+		e.mu.Unlock()
+	}
+}
+
+// localExpiry 本地认定的租约过期时间，额外预留 TTL 的 1/10 作为时钟误差余量，
+// 保证本地先于 Redis 认为租约过期，避免新旧 leader 同时执行
+func (e *Elector) localExpiry(start time.Time) time.Time {
+	return start.Add(e.ttl - e.ttl/10)
+}
@@ -0,0 +1,109 @@
+package lock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestElectorTakeoverAfterTTL(t *testing.T) {
+	mr, client := newTestRedis(t)
+	const ttl = 3 * time.Second
+	a := NewElector(NewLocker(client, "a"), "leader", ttl)
+	b := NewElector(NewLocker(client, "b"), "leader", ttl)
+
+	a.tick()
+	b.tick()
+	tokenA, ok := a.Token()
+	if !ok {
+		t.Fatal("a 未成为 leader")
+	}
+	if b.IsLeader() {
+		t.Fatal("a 持有租约时 b 不应成为 leader")
+	}
+
+	// a 未续期（如进程卡住），Redis 中的租约过期后 b 接管
+	mr.FastForward(ttl)
+	b.tick()
+	tokenB, ok := b.Token()
+	if !ok {
+		t.Fatal("租约过期后 b 未接管")
+	}
+	if tokenB <= tokenA {
+		t.Fatalf("b 的 token = %d, 未大于 a 的 %d", tokenB, tokenA)
+	}
+
+	// a 恢复后续期失败，放弃 leader 身份
+	a.tick()
+	if a.IsLeader() {
+		t.Fatal("租约被接管后 a 仍为 leader")
+	}
+}
+
+func TestElectorLocalExpiry(t *testing.T) {
+	_, client := newTestRedis(t)
+	const ttl = 3 * time.Second
+	e := NewElector(NewLocker(client, "a"), "leader", ttl)
+
+	before := time.Now()
+	e.tick()
+	if !e.IsLeader() {
+		t.Fatal("未成为 leader")
+	}
+	// 本地过期时间从请求发出前开始计算并预留余量，必须早于 Redis 中的租约过期时间
+	if limit := before.Add(ttl); !e.expiresAt.Before(limit) {
+		t.Fatalf("expiresAt = %v, 应早于 %v", e.expiresAt, limit)
+	}
+
+	e.mu.Lock()
+	e.expiresAt = time.Now().Add(-time.Millisecond)
+	e.mu.Unlock()
+	if e.IsLeader() {
+		t.Fatal("本地租约过期后仍为 leader")
+	}
+}
+
+func TestElectorStopReleasesLease(t *testing.T) {
+	mr, client := newTestRedis(t)
+	e := NewElector(NewLocker(client, "a"), "leader", 3*time.Second)
+
+	e.Start()
+	deadline := time.Now().Add(time.Second)
+	for !e.IsLeader() {
+		if time.Now().After(deadline) {
+			t.Fatal("未成为 leader")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	e.Stop()
+	if e.IsLeader() {
+		t.Fatal("Stop 后仍为 leader")
+	}
+	if mr.Exists("leader") {
+		t.Fatal("Stop 后租约未释放")
+	}
+}
+
+func TestElectorStopWithoutStart(t *testing.T) {
+	mr, client := newTestRedis(t)
+	e := NewElector(NewLocker(client, "a"), "leader", 3*time.Second)
+
+	stopped := make(chan struct{})
+	go func() {
+		e.Stop()
+		e.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("未启动的 Elector Stop 阻塞")
+	}
+
+	// Stop 之后 Start 不再竞选
+	e.Start()
+	time.Sleep(50 * time.Millisecond)
+	if e.IsLeader() || mr.Exists("leader") {
+		t.Fatal("Stop 之后 Start 仍参与竞选")
+	}
+}
@@ -0,0 +1,154 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrNotAcquired 锁已被其他实例持有
+	ErrNotAcquired = errors.New("lock: not acquired")
+	// ErrLeaseLost 租约已过期或被其他实例抢占
+	ErrLeaseLost = errors.New("lock: lease lost")
+)
+
+// acquireScript 加锁成功（或本实例已持有）时返回 fencing token，否则返回 0
+// KEYS[1]: 锁 key，KEYS[2]: fencing 计数器 key；ARGV[1]: owner，ARGV[2]: ttl（毫秒）
+var acquireScript = redis.NewScript(`
+local owner = redis.call('GET', KEYS[1])
+if owner == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return tonumber(redis.call('GET', KEYS[2]) or '0')
+end
+if owner then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return redis.call('INCR', KEYS[2])
+`)
+
+// renewScript 仅当 owner 匹配时续期
+var renewScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript 仅当 owner 匹配时删除
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Locker 基于 Redis 的租约锁，owner 用于区分实例
+type Locker struct {
+	client redis.Cmdable
+	owner  string
+}
+
+func NewLocker(client redis.Cmdable, owner string) *Locker {
+	return &Locker{
+		client: client,
+		owner:  owner,
+	}
+}
+
+// Owner 当前实例标识
+func (l *Locker) Owner() string {
+	return l.owner
+}
+
+// Lease 已获得的租约，Token 为单调递增的 fencing token
+type Lease struct {
+	locker *Locker
+	key    string
+	token  int64
+	ttl    time.Duration
+}
+
+// Acquire 尝试获取租约，锁被其他实例持有时返回 ErrNotAcquired
+func (l *Locker) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lease, error) {
+	token, err := acquireScript.Run(ctx, l.client, []string{key, fencingKey(key)}, l.owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, err
+	}
+	if token == 0 {
+		return nil, ErrNotAcquired
+	}
+	return &Lease{
+		locker: l,
+		key:    key,
+		token:  token,
+		ttl:    ttl,
+	}, nil
+}
+
+// Key 锁 key
+func (l *Lease) Key() string {
+	return l.key
+}
+
+// Token fencing token，每次重新加锁都会递增，可用于拒绝过期持有者的写入
+func (l *Lease) Token() int64 {
+	return l.token
+}
+
+// TTL 租约时长
+func (l *Lease) TTL() time.Duration {
+	return l.ttl
+}
+
+// Renew 续期，租约已丢失时返回 ErrLeaseLost
+func (l *Lease) Renew(ctx context.Context) error {
+	ok, err := renewScript.Run(ctx, l.locker.client, []string{l.key}, l.locker.owner, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Release 释放租约，租约已不属于本实例时不做任何操作
+func (l *Lease) Release(ctx context.Context) error {
+	return releaseScript.Run(ctx, l.locker.client, []string{l.key}, l.locker.owner).Err()
+}
+
+// KeepAlive 按 TTL/3 的间隔自动续期，返回的 context 在租约丢失或调用 cancel 时取消
+func (l *Lease) KeepAlive(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	go func() {
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+		deadline := time.Now().Add(l.ttl)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				start := time.Now()
+				err := l.Renew(ctx)
+				switch {
+				case err == nil:
+					deadline = start.Add(l.ttl)
+				case errors.Is(err, ErrLeaseLost), time.Now().After(deadline):
+					// 租约被抢占，或 Redis 不可用时间超过 TTL（锁可能已过期）
+					cancel(ErrLeaseLost)
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+func fencingKey(key string) string {
+	return key + ":fencing"
+}
@@ -0,0 +1,116 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
+
+func TestLeaseAcquireRenewRelease(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+	a := NewLocker(client, "a")
+	b := NewLocker(client, "b")
+
+	lease, err := a.Acquire(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if got := mr.TTL("job"); got != time.Second {
+		t.Fatalf("TTL = %v, want 1s", got)
+	}
+	if _, err := b.Acquire(ctx, "job", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Fatalf("其他实例 Acquire 错误 = %v, want ErrNotAcquired", err)
+	}
+
+	// 本实例重复加锁返回同一个 token 并续期
+	mr.FastForward(500 * time.Millisecond)
+	again, err := a.Acquire(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatalf("重复 Acquire: %v", err)
+	}
+	if again.Token() != lease.Token() {
+		t.Fatalf("重复 Acquire token = %d, want %d", again.Token(), lease.Token())
+	}
+
+	mr.FastForward(500 * time.Millisecond)
+	if err := lease.Renew(ctx); err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+	if got := mr.TTL("job"); got != time.Second {
+		t.Fatalf("续期后 TTL = %v, want 1s", got)
+	}
+
+	if err := lease.Release(ctx); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if mr.Exists("job") {
+		t.Fatal("Release 后 key 仍存在")
+	}
+	if err := lease.Renew(ctx); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("Release 后 Renew 错误 = %v, want ErrLeaseLost", err)
+	}
+}
+
+func TestLeaseReleaseKeepsOtherOwner(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+
+	stale, err := NewLocker(client, "a").Acquire(ctx, "job", time.Second)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	mr.FastForward(time.Second)
+	if _, err := NewLocker(client, "b").Acquire(ctx, "job", time.Second); err != nil {
+		t.Fatalf("过期后 Acquire: %v", err)
+	}
+
+	if err := stale.Release(ctx); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if owner, _ := mr.Get("job"); owner != "b" {
+		t.Fatalf("过期持有者 Release 后 owner = %q, want b", owner)
+	}
+	if err := stale.Renew(ctx); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("过期持有者 Renew 错误 = %v, want ErrLeaseLost", err)
+	}
+}
+
+func TestLeaseFencingTokenMonotonic(t *testing.T) {
+	mr, client := newTestRedis(t)
+	ctx := context.Background()
+	lockers := []*Locker{NewLocker(client, "a"), NewLocker(client, "b")}
+
+	var last int64
+	for i := 0; i < 6; i++ {
+		lease, err := lockers[i%2].Acquire(ctx, "job", time.Second)
+		if err != nil {
+			t.Fatalf("第 %d 次 Acquire: %v", i+1, err)
+		}
+		if lease.Token() <= last {
+			t.Fatalf("第 %d 次 token = %d, 未大于上一次 %d", i+1, lease.Token(), last)
+		}
+		last = lease.Token()
+
+		// 交替使用 Release 和过期两种方式让出锁
+		if i%2 == 0 {
+			if err := lease.Release(ctx); err != nil {
+				t.Fatalf("Release: %v", err)
+			}
+		} else {
+			mr.FastForward(time.Second)
+		}
+	}
+}
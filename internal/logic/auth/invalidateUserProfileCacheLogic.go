@@ -0,0 +1,53 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package auth
+
+import (
+	"context"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type InvalidateUserProfileCacheLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewInvalidateUserProfileCacheLogic(ctx context.Context, svcCtx *svc.ServiceContext) *InvalidateUserProfileCacheLogic {
+	return &InvalidateUserProfileCacheLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *InvalidateUserProfileCacheLogic) InvalidateUserProfileCache(req *types.InvalidateUserProfileCacheRequest) (resp *types.InvalidateUserProfileCacheResponse, err error) {
+	const trace = "Auth.InvalidateUserProfileCache"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.svcCtx.ProfileCache.InvalidateUser(l.ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	l.svcCtx.Writer.Info("清除用户认证缓存",
+		writer.Field("log_type", "user"),
+		writer.Field("trace", trace),
+		tracing.TraceIDField(l.ctx),
+		tracing.SpanIDField(l.ctx),
+		writer.Field("user_id", currentUser.ID),
+		writer.Field("username", currentUser.Name),
+		writer.Field("target_user_id", req.UserID),
+	)
+	return &types.InvalidateUserProfileCacheResponse{}, nil
+}
@@ -0,0 +1,30 @@
+package crontask
+
+import (
+	"time"
+
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/types"
+)
+
+func toCronTask(task *models.CronTask) types.CronTask {
+	return types.CronTask{
+		ID:                task.ID,
+		Name:              task.Name,
+		CronExpr:          task.CronExpr,
+		Handler:           task.Handler,
+		Enabled:           task.Enabled,
+		Timeout:           task.Timeout,
+		MaxAttempts:       task.MaxAttempts,
+		RetryBackoff:      task.RetryBackoff,
+		RetryBackoffMax:   task.RetryBackoffMax,
+		RetryJitter:       task.RetryJitter,
+		RetryOnTimeout:    boolWithDefault(task.RetryOnTimeout, true),
+		MisfirePolicy:     task.MisfirePolicy,
+		MaxCatchup:        task.MaxCatchup,
+		ConcurrencyPolicy: task.ConcurrencyPolicy,
+		Description:       task.Description,
+		CreatedAt:         task.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         task.UpdatedAt.Format(time.RFC3339),
+	}
+}
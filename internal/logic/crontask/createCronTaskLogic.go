@@ -0,0 +1,80 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type CreateCronTaskLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateCronTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateCronTaskLogic {
+	return &CreateCronTaskLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateCronTaskLogic) CreateCronTask(req *types.CreateCronTaskRequest) (resp *types.CreateCronTaskResponse, err error) {
+	const trace = "CronTask.CreateCronTask"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDefinition(l.svcCtx, req.CronExpr, req.Handler); err != nil {
+		return nil, err
+	}
+	if err := checkNameAvailable(l.ctx, l.svcCtx, trace, req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	task := &models.CronTask{
+		Name:              req.Name,
+		CronExpr:          req.CronExpr,
+		Handler:           req.Handler,
+		Enabled:           boolWithDefault(req.Enabled, true),
+		Timeout:           req.Timeout,
+		MaxAttempts:       req.MaxAttempts,
+		RetryBackoff:      req.RetryBackoff,
+		RetryBackoffMax:   req.RetryBackoffMax,
+		RetryJitter:       req.RetryJitter,
+		RetryOnTimeout:    boolWithDefault(req.RetryOnTimeout, true),
+		MisfirePolicy:     req.MisfirePolicy,
+		MaxCatchup:        req.MaxCatchup,
+		ConcurrencyPolicy: req.ConcurrencyPolicy,
+		Description:       req.Description,
+	}
+	if err := l.svcCtx.Repository.CronTask.Create(l.ctx, task); err != nil {
+		l.svcCtx.Writer.Error("创建定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("task_name", req.Name),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	reload(l.ctx, l.svcCtx, trace)
+	auditLog(l.ctx, l.svcCtx, "创建定时任务", trace, currentUser, task)
+
+	return &types.CreateCronTaskResponse{
+		Task: toCronTask(task),
+	}, nil
+}
@@ -0,0 +1,59 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type DeleteCronTaskLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteCronTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteCronTaskLogic {
+	return &DeleteCronTaskLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteCronTaskLogic) DeleteCronTask(req *types.DeleteCronTaskRequest) (resp *types.DeleteCronTaskResponse, err error) {
+	const trace = "CronTask.DeleteCronTask"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	task, err := getTask(l.ctx, l.svcCtx, trace, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.svcCtx.Repository.CronTask.Delete(l.ctx, task.ID); err != nil {
+		l.svcCtx.Writer.Error("删除定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("task_id", task.ID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	reload(l.ctx, l.svcCtx, trace)
+	auditLog(l.ctx, l.svcCtx, "删除定时任务", trace, currentUser, task)
+
+	return &types.DeleteCronTaskResponse{}, nil
+}
@@ -0,0 +1,37 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetCronTaskLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetCronTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCronTaskLogic {
+	return &GetCronTaskLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetCronTaskLogic) GetCronTask(req *types.GetCronTaskRequest) (resp *types.GetCronTaskResponse, err error) {
+	task, err := getTask(l.ctx, l.svcCtx, "CronTask.GetCronTask", req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.GetCronTaskResponse{
+		Task: toCronTask(task),
+	}, nil
+}
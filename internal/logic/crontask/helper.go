@@ -0,0 +1,167 @@
+package crontask
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// getTask 查询任务，不存在时返回 404
+func getTask(ctx context.Context, svcCtx *svc.ServiceContext, trace string, id int64) (*models.CronTask, error) {
+	task, err := svcCtx.Repository.CronTask.GetByID(ctx, id)
+	if err != nil {
+		svcCtx.Writer.Error("查询定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(ctx),
+			tracing.SpanIDField(ctx),
+			writer.Field("task_id", id),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	if task == nil {
+		return nil, response.NewError(http.StatusNotFound, "任务不存在")
+	}
+	return task, nil
+}
+
+// checkNameAvailable 校验任务名称未被其他任务占用
+func checkNameAvailable(ctx context.Context, svcCtx *svc.ServiceContext, trace string, name string, excludeID int64) error {
+	existing, err := svcCtx.Repository.CronTask.GetByName(ctx, name)
+	if err != nil {
+		svcCtx.Writer.Error("查询定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(ctx),
+			tracing.SpanIDField(ctx),
+			writer.Field("task_name", name),
+			writer.Field("error", err.Error()),
+		)
+		return err
+	}
+	if existing != nil && existing.ID != excludeID {
+		return response.NewError(http.StatusConflict, "任务名称已存在")
+	}
+	return nil
+}
+
+// validateDefinition 校验 cron 表达式与处理器
+func validateDefinition(svcCtx *svc.ServiceContext, cronExpr, handler string) error {
+	if err := scheduler.ValidateSpec(cronExpr); err != nil {
+		return response.NewError(http.StatusBadRequest, fmt.Sprintf("cron 表达式无效: %s", err.Error()))
+	}
+	if !svcCtx.Scheduler.HasHandler(handler) {
+		return response.NewError(http.StatusBadRequest, fmt.Sprintf("处理器未注册: %s", handler))
+	}
+	return nil
+}
+
+// taskUpdates 按请求中不为 nil 的字段构建要更新的列（key 为列名），名称未变化时不更新名称
+func taskUpdates(task *models.CronTask, req *types.UpdateCronTaskRequest) map[string]any {
+	updates := make(map[string]any)
+	if req.Name != nil && *req.Name != task.Name {
+		updates["name"] = *req.Name
+	}
+	if req.CronExpr != nil {
+		updates["cron_expr"] = *req.CronExpr
+	}
+	if req.Handler != nil {
+		updates["handler"] = *req.Handler
+	}
+	if req.Enabled != nil {
+		updates["enabled"] = req.Enabled
+	}
+	if req.Timeout != nil {
+		updates["timeout"] = *req.Timeout
+	}
+	if req.MaxAttempts != nil {
+		updates["max_attempts"] = *req.MaxAttempts
+	}
+	if req.RetryBackoff != nil {
+		updates["retry_backoff"] = *req.RetryBackoff
+	}
+	if req.RetryBackoffMax != nil {
+		updates["retry_backoff_max"] = *req.RetryBackoffMax
+	}
+	if req.RetryJitter != nil {
+		updates["retry_jitter"] = *req.RetryJitter
+	}
+	if req.RetryOnTimeout != nil {
+		updates["retry_on_timeout"] = req.RetryOnTimeout
+	}
+	if req.MisfirePolicy != nil {
+		updates["misfire_policy"] = *req.MisfirePolicy
+	}
+	if req.MaxCatchup != nil {
+		updates["max_catchup"] = *req.MaxCatchup
+	}
+	if req.ConcurrencyPolicy != nil {
+		updates["concurrency_policy"] = *req.ConcurrencyPolicy
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	return updates
+}
+
+// updateTask 更新任务并通知调度器重新加载，返回更新后的任务
+func updateTask(ctx context.Context, svcCtx *svc.ServiceContext, trace string, id int64, updates map[string]any) (*models.CronTask, error) {
+	if err := svcCtx.Repository.CronTask.Update(ctx, id, updates); err != nil {
+		svcCtx.Writer.Error("更新定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(ctx),
+			tracing.SpanIDField(ctx),
+			writer.Field("task_id", id),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	reload(ctx, svcCtx, trace)
+	return getTask(ctx, svcCtx, trace, id)
+}
+
+// reload 通知调度器重新加载任务，失败时由定期同步兜底，不影响接口返回
+func reload(ctx context.Context, svcCtx *svc.ServiceContext, trace string) {
+	if err := svcCtx.Scheduler.Reload(ctx); err != nil {
+		svcCtx.Writer.Error("重新加载定时任务失败",
+			writer.Field("log_type", "system"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(ctx),
+			tracing.SpanIDField(ctx),
+			writer.Field("error", err.Error()),
+		)
+	}
+}
+
+// auditLog 记录任务变更审计日志
+func auditLog(ctx context.Context, svcCtx *svc.ServiceContext, msg, trace string, currentUser *types.User, task *models.CronTask) {
+	svcCtx.Writer.Info(msg,
+		writer.Field("log_type", "user"),
+		writer.Field("trace", trace),
+		tracing.TraceIDField(ctx),
+		tracing.SpanIDField(ctx),
+		writer.Field("user_id", currentUser.ID),
+		writer.Field("username", currentUser.Name),
+		writer.Field("task_id", task.ID),
+		writer.Field("task_name", task.Name),
+	)
+}
+
+// boolWithDefault 如果为 nil 返回默认值指针
+func boolWithDefault(b *bool, defaultValue bool) *bool {
+	if b == nil {
+		return &defaultValue
+	}
+	return b
+}
@@ -0,0 +1,115 @@
+package crontask
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"testing"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+)
+
+func TestValidateDefinition(t *testing.T) {
+	sched := scheduler.NewScheduler(config.SchedulerConfig{}, nil, nil, nil)
+	sched.AddHandlers(scheduler.Route{Name: "noop", Handler: func(context.Context) error { return nil }})
+	svcCtx := &svc.ServiceContext{Scheduler: sched}
+
+	tests := []struct {
+		name     string
+		cronExpr string
+		handler  string
+		wantErr  bool
+	}{
+		{"标准 5 段", "*/5 * * * *", "noop", false},
+		{"描述符", "@every 1m", "noop", false},
+		{"6 段（含秒）不支持", "0 */5 * * * *", "noop", true},
+		{"表达式无效", "not a cron", "noop", true},
+		{"空表达式", "", "noop", true},
+		{"处理器未注册", "@daily", "missing", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDefinition(svcCtx, tt.cronExpr, tt.handler)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("validateDefinition 错误 = %v, want nil", err)
+				}
+				return
+			}
+			var respErr *response.Error
+			if !errors.As(err, &respErr) || respErr.Code != http.StatusBadRequest {
+				t.Fatalf("validateDefinition 错误 = %v, want 400", err)
+			}
+		})
+	}
+}
+
+func TestTaskUpdates(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	intPtr := func(n int) *int { return &n }
+	boolPtr := func(b bool) *bool { return &b }
+	task := &models.CronTask{ID: 1, Name: "sync", CronExpr: "@daily", Handler: "noop"}
+
+	tests := []struct {
+		name string
+		req  types.UpdateCronTaskRequest
+		want map[string]any
+	}{
+		{"没有字段", types.UpdateCronTaskRequest{}, map[string]any{}},
+		{"名称未变化不更新", types.UpdateCronTaskRequest{Name: ptr("sync")}, map[string]any{}},
+		{"名称变化", types.UpdateCronTaskRequest{Name: ptr("sync2")}, map[string]any{"name": "sync2"}},
+		{
+			"表达式和处理器",
+			types.UpdateCronTaskRequest{CronExpr: ptr("@hourly"), Handler: ptr("other")},
+			map[string]any{"cron_expr": "@hourly", "handler": "other"},
+		},
+		{
+			"零值也更新",
+			types.UpdateCronTaskRequest{Timeout: intPtr(0), MaxCatchup: intPtr(0), Description: ptr("")},
+			map[string]any{"timeout": 0, "max_catchup": 0, "description": ""},
+		},
+		{
+			"重试和并发策略",
+			types.UpdateCronTaskRequest{
+				MaxAttempts:       intPtr(3),
+				RetryBackoff:      intPtr(10),
+				RetryBackoffMax:   intPtr(60),
+				MisfirePolicy:     ptr(models.MisfireFireOnce),
+				ConcurrencyPolicy: ptr(models.ConcurrencyForbid),
+			},
+			map[string]any{
+				"max_attempts":       3,
+				"retry_backoff":      10,
+				"retry_backoff_max":  60,
+				"misfire_policy":     models.MisfireFireOnce,
+				"concurrency_policy": models.ConcurrencyForbid,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskUpdates(task, &tt.req)
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("taskUpdates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// *bool 列保留指针，false 也写入（暂停等价于 enabled = false）
+	got := taskUpdates(task, &types.UpdateCronTaskRequest{Enabled: boolPtr(false), RetryOnTimeout: boolPtr(true)})
+	if len(got) != 2 {
+		t.Fatalf("taskUpdates = %v, want enabled, retry_on_timeout", got)
+	}
+	if enabled, ok := got["enabled"].(*bool); !ok || *enabled {
+		t.Fatalf("enabled = %v, want false", got["enabled"])
+	}
+	if retry, ok := got["retry_on_timeout"].(*bool); !ok || !*retry {
+		t.Fatalf("retry_on_timeout = %v, want true", got["retry_on_timeout"])
+	}
+}
@@ -0,0 +1,57 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+
+	"go-zero-template/internal/db"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type ListCronTasksLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListCronTasksLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListCronTasksLogic {
+	return &ListCronTasksLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListCronTasksLogic) ListCronTasks(req *types.ListCronTasksRequest) (resp *types.ListCronTasksResponse, err error) {
+	tasks, total, err := l.svcCtx.Repository.CronTask.List(l.ctx, db.CronTaskFilter{
+		Name:    req.Name,
+		Handler: req.Handler,
+		Enabled: req.Enabled,
+	}, req.Page, req.PageSize)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询定时任务列表失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "CronTask.ListCronTasks"),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	list := make([]types.CronTask, 0, len(tasks))
+	for i := range tasks {
+		list = append(list, toCronTask(&tasks[i]))
+	}
+	return &types.ListCronTasksResponse{
+		Total: total,
+		List:  list,
+	}, nil
+}
@@ -0,0 +1,50 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PauseCronTaskLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPauseCronTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PauseCronTaskLogic {
+	return &PauseCronTaskLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *PauseCronTaskLogic) PauseCronTask(req *types.PauseCronTaskRequest) (resp *types.PauseCronTaskResponse, err error) {
+	const trace = "CronTask.PauseCronTask"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getTask(l.ctx, l.svcCtx, trace, req.ID); err != nil {
+		return nil, err
+	}
+
+	task, err := updateTask(l.ctx, l.svcCtx, trace, req.ID, map[string]any{"enabled": false})
+	if err != nil {
+		return nil, err
+	}
+	auditLog(l.ctx, l.svcCtx, "暂停定时任务", trace, currentUser, task)
+
+	return &types.PauseCronTaskResponse{
+		Task: toCronTask(task),
+	}, nil
+}
@@ -0,0 +1,50 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ResumeCronTaskLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewResumeCronTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ResumeCronTaskLogic {
+	return &ResumeCronTaskLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ResumeCronTaskLogic) ResumeCronTask(req *types.ResumeCronTaskRequest) (resp *types.ResumeCronTaskResponse, err error) {
+	const trace = "CronTask.ResumeCronTask"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getTask(l.ctx, l.svcCtx, trace, req.ID); err != nil {
+		return nil, err
+	}
+
+	task, err := updateTask(l.ctx, l.svcCtx, trace, req.ID, map[string]any{"enabled": true})
+	if err != nil {
+		return nil, err
+	}
+	auditLog(l.ctx, l.svcCtx, "恢复定时任务", trace, currentUser, task)
+
+	return &types.ResumeCronTaskResponse{
+		Task: toCronTask(task),
+	}, nil
+}
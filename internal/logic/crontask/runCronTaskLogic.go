@@ -0,0 +1,58 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RunCronTaskLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRunCronTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RunCronTaskLogic {
+	return &RunCronTaskLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RunCronTaskLogic) RunCronTask(req *types.RunCronTaskRequest) (resp *types.RunCronTaskResponse, err error) {
+	const trace = "CronTask.RunCronTask"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	task, err := getTask(l.ctx, l.svcCtx, trace, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	runID, err := l.svcCtx.Scheduler.RunNow(*task)
+	if errors.Is(err, scheduler.ErrOverlap) {
+		return nil, response.NewError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return nil, response.NewError(http.StatusBadRequest, err.Error())
+	}
+	auditLog(l.ctx, l.svcCtx, "手动执行定时任务", trace, currentUser, task)
+
+	return &types.RunCronTaskResponse{
+		RunID: runID,
+	}, nil
+}
@@ -0,0 +1,73 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package crontask
+
+import (
+	"context"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateCronTaskLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateCronTaskLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateCronTaskLogic {
+	return &UpdateCronTaskLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateCronTaskLogic) UpdateCronTask(req *types.UpdateCronTaskRequest) (resp *types.UpdateCronTaskResponse, err error) {
+	const trace = "CronTask.UpdateCronTask"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	task, err := getTask(l.ctx, l.svcCtx, trace, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := taskUpdates(task, req)
+	if name, ok := updates["name"].(string); ok {
+		if err := checkNameAvailable(l.ctx, l.svcCtx, trace, name, task.ID); err != nil {
+			return nil, err
+		}
+	}
+	if req.CronExpr != nil || req.Handler != nil {
+		cronExpr, handler := task.CronExpr, task.Handler
+		if req.CronExpr != nil {
+			cronExpr = *req.CronExpr
+		}
+		if req.Handler != nil {
+			handler = *req.Handler
+		}
+		if err := validateDefinition(l.svcCtx, cronExpr, handler); err != nil {
+			return nil, err
+		}
+	}
+	if len(updates) == 0 {
+		return &types.UpdateCronTaskResponse{Task: toCronTask(task)}, nil
+	}
+
+	task, err = updateTask(l.ctx, l.svcCtx, trace, req.ID, updates)
+	if err != nil {
+		return nil, err
+	}
+	auditLog(l.ctx, l.svcCtx, "更新定时任务", trace, currentUser, task)
+
+	return &types.UpdateCronTaskResponse{
+		Task: toCronTask(task),
+	}, nil
+}
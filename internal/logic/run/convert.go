@@ -0,0 +1,33 @@
+package run
+
+import (
+	"time"
+
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/types"
+)
+
+func toCronJobRun(run *models.CronJobRun) types.CronJobRun {
+	out := types.CronJobRun{
+		ID:           run.ID,
+		TaskID:       run.TaskID,
+		TaskName:     run.TaskName,
+		Handler:      run.Handler,
+		Trigger:      run.Trigger,
+		Status:       run.Status,
+		Attempts:     run.Attempts,
+		RedriveOf:    run.RedriveOf,
+		StartedAt:    run.StartedAt.Format(time.RFC3339),
+		DurationMs:   run.DurationMs,
+		Error:        run.Error,
+		Instance:     run.Instance,
+		FencingToken: run.FencingToken,
+	}
+	if run.ScheduledAt != nil {
+		out.ScheduledAt = run.ScheduledAt.Format(time.RFC3339)
+	}
+	if run.FinishedAt != nil {
+		out.FinishedAt = run.FinishedAt.Format(time.RFC3339)
+	}
+	return out
+}
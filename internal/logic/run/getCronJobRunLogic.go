@@ -0,0 +1,53 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package run
+
+import (
+	"context"
+	"net/http"
+
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type GetCronJobRunLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetCronJobRunLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCronJobRunLogic {
+	return &GetCronJobRunLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetCronJobRunLogic) GetCronJobRun(req *types.GetCronJobRunRequest) (resp *types.GetCronJobRunResponse, err error) {
+	run, err := l.svcCtx.Repository.CronJobRun.GetByID(l.ctx, req.ID)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询任务运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "CronJobRun.GetCronJobRun"),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("run_id", req.ID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	if run == nil {
+		return nil, response.NewError(http.StatusNotFound, "运行记录不存在")
+	}
+
+	return &types.GetCronJobRunResponse{
+		Run: toCronJobRun(run),
+	}, nil
+}
@@ -0,0 +1,21 @@
+package run
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-zero-template/internal/response"
+)
+
+// parseTimeParam 解析 RFC3339 格式的时间参数，空字符串返回 nil
+func parseTimeParam(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, response.NewError(http.StatusBadRequest, fmt.Sprintf("%s 格式错误，应为 RFC3339", name))
+	}
+	return &t, nil
+}
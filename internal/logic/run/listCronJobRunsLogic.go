@@ -0,0 +1,69 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package run
+
+import (
+	"context"
+
+	"go-zero-template/internal/db"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type ListCronJobRunsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListCronJobRunsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListCronJobRunsLogic {
+	return &ListCronJobRunsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListCronJobRunsLogic) ListCronJobRuns(req *types.ListCronJobRunsRequest) (resp *types.ListCronJobRunsResponse, err error) {
+	startFrom, err := parseTimeParam("start_from", req.StartFrom)
+	if err != nil {
+		return nil, err
+	}
+	startTo, err := parseTimeParam("start_to", req.StartTo)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, total, err := l.svcCtx.Repository.CronJobRun.List(l.ctx, db.CronJobRunFilter{
+		TaskID:    req.TaskID,
+		TaskName:  req.TaskName,
+		Trigger:   req.Trigger,
+		Status:    req.Status,
+		StartFrom: startFrom,
+		StartTo:   startTo,
+	}, req.Page, req.PageSize)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询任务运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "CronJobRun.ListCronJobRuns"),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	list := make([]types.CronJobRun, 0, len(runs))
+	for i := range runs {
+		list = append(list, toCronJobRun(&runs[i]))
+	}
+	return &types.ListCronJobRunsResponse{
+		Total: total,
+		List:  list,
+	}, nil
+}
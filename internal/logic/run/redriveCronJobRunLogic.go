@@ -0,0 +1,135 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package run
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type RedriveCronJobRunLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRedriveCronJobRunLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RedriveCronJobRunLogic {
+	return &RedriveCronJobRunLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RedriveCronJobRunLogic) RedriveCronJobRun(req *types.RedriveCronJobRunRequest) (resp *types.RedriveCronJobRunResponse, err error) {
+	const trace = "CronJobRun.RedriveCronJobRun"
+
+	currentUser, err := middleware.LoadUserFromContext(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := l.svcCtx.Repository.CronJobRun.GetByID(l.ctx, req.ID)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询任务运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("run_id", req.ID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	if run == nil {
+		return nil, response.NewError(http.StatusNotFound, "运行记录不存在")
+	}
+	if run.Status != models.RunStatusDeadLetter {
+		return nil, response.NewError(http.StatusConflict, "只能重新投递死信状态的运行记录，不可重试的失败（如 Permanent 错误）不会进入死信")
+	}
+
+	task, err := l.svcCtx.Repository.CronTask.GetByID(l.ctx, run.TaskID)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("task_id", run.TaskID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	if task == nil {
+		return nil, response.NewError(http.StatusNotFound, "任务不存在")
+	}
+
+	if !l.svcCtx.Scheduler.HasHandler(task.Handler) {
+		return nil, response.NewError(http.StatusBadRequest, "处理器未注册: "+task.Handler)
+	}
+
+	// 先抢占死信状态，避免并发请求重复投递
+	ok, err := l.svcCtx.Repository.CronJobRun.MarkRedriven(l.ctx, run.ID)
+	if err != nil {
+		l.svcCtx.Writer.Error("更新任务运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("run_id", run.ID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	if !ok {
+		return nil, response.NewError(http.StatusConflict, "运行记录已被重新投递")
+	}
+
+	runID, err := l.svcCtx.Scheduler.Redrive(*task, run)
+	if err != nil {
+		// 未能投递时恢复死信状态，便于稍后重试
+		if restoreErr := l.svcCtx.Repository.CronJobRun.RestoreDeadLetter(l.ctx, run.ID); restoreErr != nil {
+			l.svcCtx.Writer.Error("更新任务运行记录失败",
+				writer.Field("log_type", "database"),
+				writer.Field("trace", trace),
+				tracing.TraceIDField(l.ctx),
+				tracing.SpanIDField(l.ctx),
+				writer.Field("run_id", run.ID),
+				writer.Field("error", restoreErr.Error()),
+			)
+		}
+		if errors.Is(err, scheduler.ErrOverlap) {
+			return nil, response.NewError(http.StatusConflict, err.Error())
+		}
+		return nil, response.NewError(http.StatusBadRequest, err.Error())
+	}
+
+	l.svcCtx.Writer.Info("重新投递死信运行",
+		writer.Field("log_type", "user"),
+		writer.Field("trace", trace),
+		tracing.TraceIDField(l.ctx),
+		tracing.SpanIDField(l.ctx),
+		writer.Field("user_id", currentUser.ID),
+		writer.Field("username", currentUser.Name),
+		writer.Field("task_name", task.Name),
+		writer.Field("run_id", run.ID),
+		writer.Field("new_run_id", runID),
+	)
+
+	return &types.RedriveCronJobRunResponse{
+		RunID: runID,
+	}, nil
+}
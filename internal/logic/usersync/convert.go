@@ -0,0 +1,42 @@
+package usersync
+
+import (
+	"time"
+
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/types"
+)
+
+func toUserSyncRun(run *models.UserSyncRun) types.UserSyncRun {
+	dryRun := run.IsDryRun()
+	out := types.UserSyncRun{
+		ID:         run.ID,
+		Source:     run.Source,
+		DryRun:     &dryRun,
+		Status:     run.Status,
+		Total:      run.Total,
+		Created:    run.Created,
+		Updated:    run.Updated,
+		Skipped:    run.Skipped,
+		Failed:     run.Failed,
+		StartedAt:  run.StartedAt.Format(time.RFC3339),
+		DurationMs: run.DurationMs,
+		Error:      run.Error,
+	}
+	if run.FinishedAt != nil {
+		out.FinishedAt = run.FinishedAt.Format(time.RFC3339)
+	}
+	return out
+}
+
+func toUserSyncItem(item *models.UserSyncItem) types.UserSyncItem {
+	return types.UserSyncItem{
+		ID:        item.ID,
+		LoginName: item.LoginName,
+		UserID:    item.UserID,
+		Action:    item.Action,
+		Changes:   item.Changes,
+		Error:     item.Error,
+		CreatedAt: item.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,40 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package usersync
+
+import (
+	"context"
+
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetUserSyncRunLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetUserSyncRunLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetUserSyncRunLogic {
+	return &GetUserSyncRunLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetUserSyncRunLogic) GetUserSyncRun(req *types.GetUserSyncRunRequest) (resp *types.GetUserSyncRunResponse, err error) {
+	const trace = "UserSync.GetUserSyncRun"
+
+	run, err := getRun(l.ctx, l.svcCtx, trace, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetUserSyncRunResponse{
+		Run: toUserSyncRun(run),
+	}, nil
+}
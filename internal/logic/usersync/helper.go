@@ -0,0 +1,33 @@
+package usersync
+
+import (
+	"context"
+	"net/http"
+
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// getRun 查询用户同步运行记录，不存在时返回 404
+func getRun(ctx context.Context, svcCtx *svc.ServiceContext, trace string, id int64) (*models.UserSyncRun, error) {
+	run, err := svcCtx.Repository.UserSyncRun.GetByID(ctx, id)
+	if err != nil {
+		svcCtx.Writer.Error("查询用户同步运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(ctx),
+			tracing.SpanIDField(ctx),
+			writer.Field("sync_run_id", id),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	if run == nil {
+		return nil, response.NewError(http.StatusNotFound, "同步运行记录不存在")
+	}
+	return run, nil
+}
@@ -0,0 +1,60 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package usersync
+
+import (
+	"context"
+
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type ListUserSyncItemsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListUserSyncItemsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListUserSyncItemsLogic {
+	return &ListUserSyncItemsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListUserSyncItemsLogic) ListUserSyncItems(req *types.ListUserSyncItemsRequest) (resp *types.ListUserSyncItemsResponse, err error) {
+	const trace = "UserSync.ListUserSyncItems"
+
+	run, err := getRun(l.ctx, l.svcCtx, trace, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := l.svcCtx.Repository.UserSyncItem.ListByRun(l.ctx, run.ID, req.Action, req.Page, req.PageSize)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询用户同步明细失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("sync_run_id", run.ID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	list := make([]types.UserSyncItem, 0, len(items))
+	for i := range items {
+		list = append(list, toUserSyncItem(&items[i]))
+	}
+	return &types.ListUserSyncItemsResponse{
+		Total: total,
+		List:  list,
+	}, nil
+}
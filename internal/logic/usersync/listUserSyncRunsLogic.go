@@ -0,0 +1,59 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package usersync
+
+import (
+	"context"
+
+	"go-zero-template/internal/db"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type ListUserSyncRunsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListUserSyncRunsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListUserSyncRunsLogic {
+	return &ListUserSyncRunsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListUserSyncRunsLogic) ListUserSyncRuns(req *types.ListUserSyncRunsRequest) (resp *types.ListUserSyncRunsResponse, err error) {
+	const trace = "UserSync.ListUserSyncRuns"
+
+	runs, total, err := l.svcCtx.Repository.UserSyncRun.List(l.ctx, db.UserSyncRunFilter{
+		Source: req.Source,
+		Status: req.Status,
+		DryRun: req.DryRun,
+	}, req.Page, req.PageSize)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询用户同步运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	list := make([]types.UserSyncRun, 0, len(runs))
+	for i := range runs {
+		list = append(list, toUserSyncRun(&runs[i]))
+	}
+	return &types.ListUserSyncRunsResponse{
+		Total: total,
+		List:  list,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"strings"
+	"time"
+
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/types"
+	"go-zero-template/internal/workflow"
+)
+
+func toWorkflow(def workflow.Definition) types.Workflow {
+	nodes := make([]types.WorkflowNode, 0, len(def.Nodes))
+	for _, node := range def.Nodes {
+		dependsOn := node.DependsOn
+		if dependsOn == nil {
+			dependsOn = []string{}
+		}
+		nodes = append(nodes, types.WorkflowNode{
+			Name:      node.Name,
+			Handler:   node.Handler,
+			DependsOn: dependsOn,
+			Timeout:   int(node.Timeout / time.Second),
+		})
+	}
+	return types.Workflow{
+		Name:        def.Name,
+		Handler:     workflow.HandlerName(def.Name),
+		Description: def.Description,
+		Nodes:       nodes,
+	}
+}
+
+func toWorkflowRun(run *models.WorkflowRun) types.WorkflowRun {
+	out := types.WorkflowRun{
+		ID:         run.ID,
+		Workflow:   run.Workflow,
+		Status:     run.Status,
+		StartedAt:  run.StartedAt.Format(time.RFC3339),
+		DurationMs: run.DurationMs,
+		Error:      run.Error,
+		Instance:   run.Instance,
+	}
+	if run.FinishedAt != nil {
+		out.FinishedAt = run.FinishedAt.Format(time.RFC3339)
+	}
+	return out
+}
+
+func toWorkflowNodeRun(run *models.WorkflowNodeRun) types.WorkflowNodeRun {
+	dependsOn := []string{}
+	if run.DependsOn != "" {
+		dependsOn = strings.Split(run.DependsOn, ",")
+	}
+	out := types.WorkflowNodeRun{
+		ID:         run.ID,
+		Node:       run.Node,
+		Handler:    run.Handler,
+		DependsOn:  dependsOn,
+		Status:     run.Status,
+		DurationMs: run.DurationMs,
+		Error:      run.Error,
+	}
+	if run.StartedAt != nil {
+		out.StartedAt = run.StartedAt.Format(time.RFC3339)
+	}
+	if run.FinishedAt != nil {
+		out.FinishedAt = run.FinishedAt.Format(time.RFC3339)
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package workflow
+
+import (
+	"context"
+	"net/http"
+
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type GetWorkflowRunLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetWorkflowRunLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetWorkflowRunLogic {
+	return &GetWorkflowRunLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetWorkflowRunLogic) GetWorkflowRun(req *types.GetWorkflowRunRequest) (resp *types.GetWorkflowRunResponse, err error) {
+	const trace = "Workflow.GetWorkflowRun"
+
+	run, err := l.svcCtx.Repository.WorkflowRun.GetByID(l.ctx, req.ID)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询工作流运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("workflow_run_id", req.ID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+	if run == nil {
+		return nil, response.NewError(http.StatusNotFound, "工作流运行记录不存在")
+	}
+
+	nodeRuns, err := l.svcCtx.Repository.WorkflowNodeRun.ListByWorkflowRun(l.ctx, run.ID)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询工作流节点运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", trace),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("workflow_run_id", req.ID),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	nodes := make([]types.WorkflowNodeRun, 0, len(nodeRuns))
+	for i := range nodeRuns {
+		nodes = append(nodes, toWorkflowNodeRun(&nodeRuns[i]))
+	}
+	return &types.GetWorkflowRunResponse{
+		Run:   toWorkflowRun(run),
+		Nodes: nodes,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package workflow
+
+import (
+	"context"
+
+	"go-zero-template/internal/db"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+type ListWorkflowRunsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWorkflowRunsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWorkflowRunsLogic {
+	return &ListWorkflowRunsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListWorkflowRunsLogic) ListWorkflowRuns(req *types.ListWorkflowRunsRequest) (resp *types.ListWorkflowRunsResponse, err error) {
+	startFrom, err := parseTimeParam("start_from", req.StartFrom)
+	if err != nil {
+		return nil, err
+	}
+	startTo, err := parseTimeParam("start_to", req.StartTo)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, total, err := l.svcCtx.Repository.WorkflowRun.List(l.ctx, db.WorkflowRunFilter{
+		Workflow:  req.Workflow,
+		Status:    req.Status,
+		StartFrom: startFrom,
+		StartTo:   startTo,
+	}, req.Page, req.PageSize)
+	if err != nil {
+		l.svcCtx.Writer.Error("查询工作流运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "Workflow.ListWorkflowRuns"),
+			tracing.TraceIDField(l.ctx),
+			tracing.SpanIDField(l.ctx),
+			writer.Field("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	list := make([]types.WorkflowRun, 0, len(runs))
+	for i := range runs {
+		list = append(list, toWorkflowRun(&runs[i]))
+	}
+	return &types.ListWorkflowRunsResponse{
+		Total: total,
+		List:  list,
+	}, nil
+}
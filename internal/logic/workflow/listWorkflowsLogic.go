@@ -0,0 +1,38 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.2
+
+package workflow
+
+import (
+	"context"
+
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListWorkflowsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListWorkflowsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListWorkflowsLogic {
+	return &ListWorkflowsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListWorkflowsLogic) ListWorkflows(req *types.ListWorkflowsRequest) (resp *types.ListWorkflowsResponse, err error) {
+	defs := l.svcCtx.Workflow.Definitions()
+	list := make([]types.Workflow, 0, len(defs))
+	for _, def := range defs {
+		list = append(list, toWorkflow(def))
+	}
+	return &types.ListWorkflowsResponse{
+		List: list,
+	}, nil
+}
@@ -5,29 +5,57 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"go-zero-template/internal/config"
-	"go-zero-template/internal/request"
 	"go-zero-template/internal/response"
 	"go-zero-template/internal/types"
+	"go-zero-template/internal/utils"
 	"net/http"
 	"strings"
+	"sync"
+
+	"github.com/zeromicro/go-zero/core/logx"
 )
 
 // contextKey 用于 context 的 key 类型
 type contextKey string
 
 const (
-	// UserContextKey 用户信息在 context 中的 key
+	// UserContextKey 认证信息在 context 中的 key
 	UserContextKey contextKey = "user"
 )
 
+// authInfo 请求的认证信息：token 在本地校验得到 payload，完整用户信息按需从缓存或 user_service 获取
+type authInfo struct {
+	token   string
+	payload *utils.TokenPayload
+	cache   *ProfileCache
+
+	once sync.Once
+	user *types.User
+	err  error
+}
+
+// loadUser 获取完整用户信息，同一请求内只获取一次
+func (a *authInfo) loadUser(ctx context.Context) (*types.User, error) {
+	a.once.Do(func() {
+		a.user, a.err = a.cache.Get(ctx, a.token, a.payload.UserID)
+	})
+	return a.user, a.err
+}
+
 type AuthMiddleware struct {
-	requestClient *request.RequestClient
+	accessSecret string
+	profileCache *ProfileCache
 }
 
-func NewAuthMiddleware(services *config.ServicesConfig) *AuthMiddleware {
+func NewAuthMiddleware(auth config.AuthConfig, profileCache *ProfileCache) *AuthMiddleware {
+	if auth.AccessSecret == "" {
+		logx.Error("Auth.AccessSecret 未配置，所有需要认证的请求都将被拒绝")
+	}
 	return &AuthMiddleware{
-		requestClient: request.NewRequestClient(services),
+		accessSecret: auth.AccessSecret,
+		profileCache: profileCache,
 	}
 }
 
@@ -47,15 +75,19 @@ func (m *AuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		resp, err := m.requestClient.GetUserInfo(token)
+		// 本地校验 token 签名、有效期和类型，不请求 user_service
+		payload, err := utils.ParseAccessToken(strings.TrimPrefix(token, "Bearer "), m.accessSecret)
 		if err != nil {
 			response.Response(w, nil, response.NewError(http.StatusUnauthorized, "认证失败"))
 			return
 		}
 
-		// 将用户信息存入 context
-		user := &resp.User
-		ctx := context.WithValue(r.Context(), UserContextKey, user)
+		// 将认证信息存入 context
+		ctx := context.WithValue(r.Context(), UserContextKey, &authInfo{
+			token:   token,
+			payload: payload,
+			cache:   m.profileCache,
+		})
 		r = r.WithContext(ctx)
 
 		// 传递给下一个 handler
@@ -63,8 +95,37 @@ func (m *AuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// GetUserFromContext 从 context 中获取用户信息
+// GetTokenPayloadFromContext 从 context 中获取本地校验后的 token payload，不请求 user_service
+func GetTokenPayloadFromContext(ctx context.Context) (*utils.TokenPayload, bool) {
+	info, ok := ctx.Value(UserContextKey).(*authInfo)
+	if !ok {
+		return nil, false
+	}
+	return info.payload, true
+}
+
+// LoadUserFromContext 从 context 中获取完整用户信息，首次调用时读取缓存，未命中时请求 user_service
+// 未经过认证中间件时返回 401 缺少认证信息，token 被 user_service 拒绝时返回 401 认证失败，其他错误返回 503
+func LoadUserFromContext(ctx context.Context) (*types.User, error) {
+	info, ok := ctx.Value(UserContextKey).(*authInfo)
+	if !ok {
+		return nil, response.NewError(http.StatusUnauthorized, "缺少认证信息")
+	}
+	user, err := info.loadUser(ctx)
+	switch {
+	case err == nil:
+		return user, nil
+	case errors.Is(err, ErrTokenRejected):
+		return nil, response.NewError(http.StatusUnauthorized, "认证失败")
+	default:
+		// user_service 不可用等非认证错误返回 503，避免上游故障时客户端误以为登录失效
+		logx.WithContext(ctx).Errorf("获取用户信息失败: user_id=%d, err=%v", info.payload.UserID, err)
+		return nil, response.NewError(http.StatusServiceUnavailable, "用户服务暂不可用")
+	}
+}
+
+// GetUserFromContext 从 context 中获取完整用户信息，见 LoadUserFromContext
 func GetUserFromContext(ctx context.Context) (*types.User, bool) {
-	user, ok := ctx.Value(UserContextKey).(*types.User)
-	return user, ok
+	user, err := LoadUserFromContext(ctx)
+	return user, err == nil
 }
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/request"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/types"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/syncx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+const (
+	// profileKeyPrefix 用户信息缓存的 Redis key 前缀，后缀为 token 的 SHA-256
+	profileKeyPrefix = "auth:profile:"
+	// profileUserKeyPrefix 用户 token 索引的 Redis key 前缀，后缀为用户ID，值为该用户已缓存的 token 哈希集合
+	profileUserKeyPrefix = "auth:profile:user:"
+	// rejectedMarker 负缓存标记，token 被 user_service 拒绝
+	rejectedMarker = "-"
+)
+
+// ErrTokenRejected token 被 user_service 拒绝（命中负缓存或 user_service 返回 401/403/404）
+var ErrTokenRejected = errors.New("token 被 user_service 拒绝")
+
+// ProfileCache 基于 Redis 的认证用户信息缓存：按 token 哈希缓存 user_service 返回的用户信息
+// 被拒绝的 token 短时间负缓存，同一 token 的并发请求合并为一次 user_service 调用
+type ProfileCache struct {
+	redis  *redis.Client
+	client *request.UserServiceClient
+	writer *writer.MultiWriter
+	config config.AuthConfig
+	flight syncx.SingleFlight
+}
+
+func NewProfileCache(c config.AuthConfig, redisClient *redis.Client, client *request.UserServiceClient, w *writer.MultiWriter) *ProfileCache {
+	return &ProfileCache{
+		redis:  redisClient,
+		client: client,
+		writer: w,
+		config: c,
+		flight: syncx.NewSingleFlight(),
+	}
+}
+
+// Get 获取 token 对应的用户信息，优先读取缓存；userID 为本地校验 token 得到的用户ID，用于按用户失效缓存
+// Redis 不可用时直接请求 user_service
+func (c *ProfileCache) Get(ctx context.Context, token string, userID int) (*types.User, error) {
+	hash := hashToken(token)
+	key := profileKeyPrefix + hash
+
+	cached, err := c.redis.Get(ctx, key).Result()
+	switch {
+	case err == nil && cached == rejectedMarker:
+		return nil, ErrTokenRejected
+	case err == nil:
+		var user types.User
+		if err := json.Unmarshal([]byte(cached), &user); err == nil {
+			return &user, nil
+		}
+	case !errors.Is(err, redis.Nil):
+		c.logRedisError(ctx, "ProfileCache.Get", userID, err)
+	}
+
+	// 同一 token 的并发请求共享一次 user_service 调用，调用不随发起请求的客户端断开而取消
+	val, err := c.flight.Do(hash, func() (any, error) {
+		return c.load(context.WithoutCancel(ctx), key, hash, token, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*types.User), nil
+}
+
+// load 请求 user_service 获取用户信息并写入缓存，token 被拒绝时写入负缓存
+func (c *ProfileCache) load(ctx context.Context, key, hash, token string, userID int) (*types.User, error) {
+	resp, err := c.client.GetUserInfo(ctx, token)
+	if err != nil {
+		if isRejected(err) {
+			if err := c.redis.Set(ctx, key, rejectedMarker, c.config.NegativeCacheTTL).Err(); err != nil {
+				c.logRedisError(ctx, "ProfileCache.load", userID, err)
+			}
+			return nil, ErrTokenRejected
+		}
+		return nil, err
+	}
+
+	user := &resp.User
+	data, err := json.Marshal(user)
+	if err != nil {
+		return user, nil
+	}
+	userKey := profileUserKeyPrefix + strconv.Itoa(userID)
+	pipe := c.redis.TxPipeline()
+	pipe.Set(ctx, key, data, c.config.ProfileCacheTTL)
+	pipe.SAdd(ctx, userKey, hash)
+	pipe.Expire(ctx, userKey, c.config.ProfileCacheTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.logRedisError(ctx, "ProfileCache.load", userID, err)
+	}
+	return user, nil
+}
+
+// isRejected user_service 是否拒绝了 token：HTTP 状态码或 BaseResponse.code 为 401/403/404
+// 两者可能不同（如 HTTP 401 携带自定义业务 code），任一命中即视为拒绝
+func isRejected(err error) bool {
+	var respErr *types.ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	for _, code := range []int{respErr.HTTPStatus, respErr.Code} {
+		if code == http.StatusUnauthorized || code == http.StatusForbidden || code == http.StatusNotFound {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidateUser 清除用户所有 token 的缓存，用户角色或信息变更后调用
+func (c *ProfileCache) InvalidateUser(ctx context.Context, userID int) error {
+	userKey := profileUserKeyPrefix + strconv.Itoa(userID)
+	hashes, err := c.redis.SMembers(ctx, userKey).Result()
+	if err != nil {
+		c.logRedisError(ctx, "ProfileCache.InvalidateUser", userID, err)
+		return err
+	}
+
+	keys := make([]string, 0, len(hashes)+1)
+	for _, hash := range hashes {
+		keys = append(keys, profileKeyPrefix+hash)
+	}
+	keys = append(keys, userKey)
+	if err := c.redis.Del(ctx, keys...).Err(); err != nil {
+		c.logRedisError(ctx, "ProfileCache.InvalidateUser", userID, err)
+		return err
+	}
+	return nil
+}
+
+func (c *ProfileCache) logRedisError(ctx context.Context, trace string, userID int, err error) {
+	c.writer.Error("用户信息缓存操作失败",
+		writer.Field("log_type", "redis"),
+		writer.Field("trace", trace),
+		tracing.TraceIDField(ctx),
+		tracing.SpanIDField(ctx),
+		writer.Field("user_id", userID),
+		writer.Field("error", err.Error()),
+	)
+}
+
+// hashToken token 的 SHA-256 十六进制，避免 Redis 中保存明文 token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/mock/userservice"
+	"go-zero-template/internal/request"
+	"go-zero-template/internal/types"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// newTestProfileCache 内嵌启动模拟 user_service，固定 token "t1" 对应用户 alice
+func newTestProfileCache(t *testing.T) (*ProfileCache, *userservice.Server, int) {
+	t.Helper()
+	srv := userservice.New(&userservice.Fixture{})
+	ts := srv.Start()
+	t.Cleanup(ts.Close)
+	alice := srv.AddUser(types.User{UserBase: types.UserBase{LoginName: "alice", Name: "Alice", Status: 1}})
+	srv.SetToken("t1", alice.ID)
+
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+
+	w := writer.NewMultiWriter(writer.NewConsoleWriter())
+	client := request.NewRegistry(config.ServicesConfig{config.UserServiceName: srv.ServiceConfig(ts.URL)}, w).UserService()
+	cache := NewProfileCache(config.AuthConfig{ProfileCacheTTL: time.Minute, NegativeCacheTTL: time.Minute}, redisClient, client, w)
+	return cache, srv, alice.ID
+}
+
+func TestProfileCacheRejected(t *testing.T) {
+	tests := []struct {
+		name         string
+		fault        userservice.Fault
+		wantRejected bool
+	}{
+		{"HTTP 401 自定义业务 code", userservice.Fault{Status: http.StatusUnauthorized, Code: 40101}, true},
+		{"HTTP 403 自定义业务 code", userservice.Fault{Status: http.StatusForbidden, Code: 10003}, true},
+		{"HTTP 404 自定义业务 code", userservice.Fault{Status: http.StatusNotFound, Code: 40404}, true},
+		{"HTTP 200 业务 code 401", userservice.Fault{Status: http.StatusOK, Code: http.StatusUnauthorized}, true},
+		{"HTTP 503 不视为拒绝", userservice.Fault{Status: http.StatusServiceUnavailable, Code: 50301}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, srv, userID := newTestProfileCache(t)
+			ctx := context.Background()
+			tt.fault.Times = 1
+			srv.SetFault(userservice.EndpointInfo, tt.fault)
+
+			_, err := cache.Get(ctx, "Bearer t1", userID)
+			if got := errors.Is(err, ErrTokenRejected); got != tt.wantRejected {
+				t.Fatalf("首次 Get 错误 = %v, 是否拒绝 = %v, want %v", err, got, tt.wantRejected)
+			}
+
+			// 故障只生效一次：拒绝时命中负缓存不再请求 user_service，否则重新请求并成功
+			user, err := cache.Get(ctx, "Bearer t1", userID)
+			if tt.wantRejected {
+				if !errors.Is(err, ErrTokenRejected) {
+					t.Fatalf("再次 Get 错误 = %v, want ErrTokenRejected", err)
+				}
+				if calls := srv.Calls(userservice.EndpointInfo); calls != 1 {
+					t.Fatalf("user_service 调用次数 = %d, want 1", calls)
+				}
+				return
+			}
+			if err != nil || user.ID != userID {
+				t.Fatalf("再次 Get = %+v, %v, want alice", user, err)
+			}
+			if calls := srv.Calls(userservice.EndpointInfo); calls != 2 {
+				t.Fatalf("user_service 调用次数 = %d, want 2", calls)
+			}
+		})
+	}
+}
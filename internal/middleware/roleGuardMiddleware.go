@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/tracing"
+	"go-zero-template/internal/utils"
+	"net/http"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// RoleGuardMiddleware 路由组角色校验，需放在 AuthMiddleware 之后
+// 当前用户角色不在权限组内时返回 403 并记录权限拒绝日志
+type RoleGuardMiddleware struct {
+	group  []utils.RoleType
+	writer *writer.MultiWriter
+}
+
+func NewRoleGuardMiddleware(group []utils.RoleType, w *writer.MultiWriter) *RoleGuardMiddleware {
+	return &RoleGuardMiddleware{
+		group:  group,
+		writer: w,
+	}
+}
+
+func (m *RoleGuardMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		currentUser, err := LoadUserFromContext(r.Context())
+		if err != nil {
+			response.Response(w, nil, err)
+			return
+		}
+
+		if !utils.RouteGuard(currentUser.RoleCode, m.group) {
+			m.writer.Error("无权限访问接口",
+				writer.Field("log_type", "permission"),
+				writer.Field("trace", "RoleGuardMiddleware.Handle"),
+				tracing.TraceIDField(r.Context()),
+				tracing.SpanIDField(r.Context()),
+				writer.Field("user_id", currentUser.ID),
+				writer.Field("username", currentUser.Name),
+				writer.Field("role_code", currentUser.RoleCode),
+				writer.Field("method", r.Method),
+				writer.Field("path", r.URL.Path),
+			)
+			response.Response(w, nil, response.NewError(http.StatusForbidden, "无权限访问该接口"))
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,105 @@
+package userservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-zero-template/internal/types"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Fixture 模拟 user_service 的初始数据，可从 YAML/JSON 文件加载，字段名与 user_service API 一致（snake_case）
+type Fixture struct {
+	Path         string            `json:"path"`          // 接口基础路径，为空时为 DefaultPath
+	AccessSecret string            `json:"access_secret"` // JWT 签名密钥，与本服务 Auth.AccessSecret 一致时登录返回的 token 可直接访问本服务
+	Users        []types.User      `json:"users"`         // 初始用户，ID 为 0 时自动分配
+	Passwords    map[string]string `json:"passwords"`     // 登录名 -> 密码，用于登录接口
+	Tokens       map[string]int    `json:"tokens"`        // 固定 token（不含 "Bearer " 前缀）-> 用户ID
+	Faults       map[string]Fault  `json:"faults"`        // 接口名 -> 注入的故障，接口名见 Endpoint* 常量，"*" 表示所有接口
+}
+
+// Fault 注入的故障：先等待 Latency，Status 不为 0 时返回错误响应
+type Fault struct {
+	Latency Duration `json:"latency"` // 响应前等待的时间，如 "200ms"
+	Status  int      `json:"status"`  // HTTP 状态码，为 0 时只注入延迟
+	Code    int      `json:"code"`    // BaseResponse.code，为 0 时与 Status 相同
+	Msg     string   `json:"msg"`     // BaseResponse.msg，为空时为 "模拟故障"
+	Times   int      `json:"times"`   // 生效次数，为 0 时一直生效
+}
+
+// Duration 支持 "200ms"、"1s" 格式的 time.Duration，数字按纳秒处理
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("无效的时长: %s", data)
+		}
+		*d = Duration(n)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("无效的时长: %w", err)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadFixture 从文件加载 Fixture，.json 文件按 JSON 解析，其他按 YAML 解析
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取 fixture 失败: %w", err)
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".json") {
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, fmt.Errorf("解析 fixture 失败: %w", err)
+		}
+	}
+
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("解析 fixture 失败: %w", err)
+	}
+	return &f, nil
+}
+
+// yamlToJSON 将 YAML 转为 JSON，使 fixture 复用 types.User 的 json tag
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(convertYAML(v))
+}
+
+// convertYAML 将 yaml.v2 解析出的 map[interface{}]interface{} 递归转为 map[string]any
+func convertYAML(v any) any {
+	switch val := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = convertYAML(item)
+		}
+		return m
+	case []any:
+		for i := range val {
+			val[i] = convertYAML(val[i])
+		}
+		return val
+	default:
+		return v
+	}
+}
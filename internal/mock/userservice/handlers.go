@@ -0,0 +1,230 @@
+package userservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-zero-template/internal/request"
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/types"
+)
+
+// defaultPageSize 列表接口未指定 page_size 时的每页数量
+const defaultPageSize = 20
+
+func (s *Server) login(r *http.Request, _ int) (any, error) {
+	var req request.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, response.NewError(http.StatusBadRequest, "解析请求失败")
+	}
+
+	s.mu.Lock()
+	password, ok := s.passwords[req.Username]
+	user := s.findUser(func(u *types.User) bool { return u.LoginName == req.Username })
+	s.mu.Unlock()
+	if !ok || password != req.Password || user == nil {
+		return nil, response.NewError(http.StatusUnauthorized, "用户名或密码错误")
+	}
+
+	token, err := s.IssueToken(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &request.LoginResponse{Token: token}, nil
+}
+
+func (s *Server) info(_ *http.Request, userID int) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[userID]
+	if !ok {
+		return nil, errUserNotFound
+	}
+	return &request.GetUserInfoResponse{User: *u}, nil
+}
+
+func (s *Server) create(r *http.Request, _ int) (any, error) {
+	var req request.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, response.NewError(http.StatusBadRequest, "解析请求失败")
+	}
+	if req.LoginName == "" {
+		return nil, response.NewError(http.StatusBadRequest, "登录名不能为空")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.findUser(func(u *types.User) bool { return u.LoginName == req.LoginName }) != nil {
+		return nil, response.NewError(http.StatusConflict, "登录名已存在")
+	}
+	u := s.saveUser(types.User{UserBase: req.UserBase})
+	if req.Password != "" {
+		s.passwords[u.LoginName] = req.Password
+	}
+	return &request.CreateUserResponse{User: *u}, nil
+}
+
+func (s *Server) update(r *http.Request, _ int) (any, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	var req request.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, response.NewError(http.StatusBadRequest, "解析请求失败")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[id]
+	if !ok {
+		return nil, errUserNotFound
+	}
+	if req.LoginName != u.LoginName && s.findUser(func(other *types.User) bool { return other.LoginName == req.LoginName }) != nil {
+		return nil, response.NewError(http.StatusConflict, "登录名已存在")
+	}
+	u.UserBase = req.UserBase
+	u.UpdatedAt = time.Now().Format(time.RFC3339)
+	return &request.UpdateUserResponse{User: *u}, nil
+}
+
+func (s *Server) delete(r *http.Request, _ int) (any, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[id]; !ok {
+		return nil, errUserNotFound
+	}
+	delete(s.users, id)
+	return &request.DeleteUserResponse{}, nil
+}
+
+func (s *Server) get(r *http.Request, _ int) (any, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[id]
+	if !ok {
+		return nil, errUserNotFound
+	}
+	return &request.GetUserResponse{User: *u}, nil
+}
+
+func (s *Server) getByLoginName(r *http.Request, _ int) (any, error) {
+	loginName := r.PathValue("loginName")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.findUser(func(u *types.User) bool { return u.LoginName == loginName })
+	if u == nil {
+		return nil, errUserNotFound
+	}
+	return &request.GetUserResponse{User: *u}, nil
+}
+
+func (s *Server) getBySAP(r *http.Request, _ int) (any, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.findUser(func(u *types.User) bool { return u.SAPEmployeeID != nil && *u.SAPEmployeeID == id })
+	if u == nil {
+		return nil, errUserNotFound
+	}
+	return &request.GetUserResponse{User: *u}, nil
+}
+
+// list 分页查询，过滤条件与 request.ListUsersRequest 一致
+func (s *Server) list(r *http.Request, _ int) (any, error) {
+	q := r.URL.Query()
+	page, pageSize := 1, defaultPageSize
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(q.Get("page_size")); err == nil && v > 0 {
+		pageSize = v
+	}
+	keyword := q.Get("keyword")
+	roleCode := q.Get("role_code")
+	status, _ := strconv.Atoi(q.Get("status"))
+	orgID, hasOrg := 0, q.Has("org_id")
+	if hasOrg {
+		orgID, _ = strconv.Atoi(q.Get("org_id"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []types.User
+	for _, u := range s.sortedUsers() {
+		switch {
+		case keyword != "" && !strings.Contains(u.Name, keyword) && !strings.Contains(u.NameOptimized, keyword) && !strings.Contains(u.LoginName, keyword):
+			continue
+		case status != 0 && int(u.Status) != status:
+			continue
+		case roleCode != "" && u.RoleCode != roleCode:
+			continue
+		case hasOrg && !inOrg(u, orgID):
+			continue
+		}
+		matched = append(matched, *u)
+	}
+
+	resp := &request.ListUsersResponse{Total: int64(len(matched)), List: []types.User{}}
+	if start := (page - 1) * pageSize; start < len(matched) {
+		resp.List = matched[start:min(start+pageSize, len(matched))]
+	}
+	return resp, nil
+}
+
+func (s *Server) batchGet(r *http.Request, _ int) (any, error) {
+	var req request.BatchGetUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, response.NewError(http.StatusBadRequest, "解析请求失败")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &request.BatchGetUsersResponse{List: []types.User{}}
+	for _, id := range req.IDs {
+		if u, ok := s.users[id]; ok {
+			resp.List = append(resp.List, *u)
+		}
+	}
+	return resp, nil
+}
+
+// pathID 解析 path 中的数字 ID
+func pathID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		return 0, response.NewError(http.StatusBadRequest, "无效的ID")
+	}
+	return id, nil
+}
+
+// inOrg 用户的当前组织或任一级组织是否为 orgID
+func inOrg(u *types.User, orgID int) bool {
+	for _, id := range []*int{
+		u.OrgID, u.OrgLevel1ID, u.OrgLevel2ID, u.OrgLevel3ID, u.OrgLevel4ID,
+		u.OrgLevel5ID, u.OrgLevel6ID, u.OrgLevel7ID, u.OrgLevel8ID, u.OrgLevel9ID,
+	} {
+		if id != nil && *id == orgID {
+			return true
+		}
+	}
+	return false
+}
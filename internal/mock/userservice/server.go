@@ -0,0 +1,383 @@
+// Package userservice 模拟 user_service，供本地开发和测试使用，不依赖真实的 user_service
+// 接口与 request.UserServiceClient 一致，响应使用 BaseResponse 结构：
+//
+//	srv := userservice.New(fixture)
+//	ts := srv.Start()
+//	defer ts.Close()
+//	client, _ := request.NewRequestClient(config.UserServiceName, srv.ServiceConfig(ts.URL), nil)
+package userservice
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/response"
+	"go-zero-template/internal/types"
+	"go-zero-template/internal/utils"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// DefaultPath 接口基础路径，与 Services.user_service.Path 默认配置一致
+const DefaultPath = "/api/v1/user"
+
+// tokenTTL 登录签发的 JWT 有效期
+const tokenTTL = 24 * time.Hour
+
+// 接口名，用于 Fault 注入和 Calls 计数
+const (
+	EndpointAll            = "*"
+	EndpointLogin          = "login"
+	EndpointInfo           = "info"
+	EndpointCreate         = "create"
+	EndpointUpdate         = "update"
+	EndpointDelete         = "delete"
+	EndpointGet            = "get"
+	EndpointGetByLoginName = "get_by_login_name"
+	EndpointGetBySAP       = "get_by_sap_employee_id"
+	EndpointList           = "list"
+	EndpointBatchGet       = "batch_get"
+)
+
+var (
+	errUnauthorized = response.NewError(http.StatusUnauthorized, "认证失败")
+	errUserNotFound = response.NewError(http.StatusNotFound, "用户不存在")
+)
+
+// Server 模拟 user_service：用户数据保存在内存中，创建、更新、删除立即生效，并发安全
+type Server struct {
+	path   string
+	secret string
+
+	mu        sync.Mutex
+	users     map[int]*types.User
+	nextID    int
+	passwords map[string]string
+	tokens    map[string]int
+	faults    map[string]*Fault
+	calls     map[string]int
+}
+
+// New 按 fixture 创建模拟服务，fixture 为 nil 时没有任何用户
+func New(f *Fixture) *Server {
+	if f == nil {
+		f = &Fixture{}
+	}
+	s := &Server{
+		path:      strings.TrimSuffix(f.Path, "/"),
+		secret:    f.AccessSecret,
+		users:     make(map[int]*types.User),
+		nextID:    1,
+		passwords: make(map[string]string),
+		tokens:    make(map[string]int),
+		faults:    make(map[string]*Fault),
+		calls:     make(map[string]int),
+	}
+	if f.Path == "" {
+		s.path = DefaultPath
+	}
+	for _, u := range f.Users {
+		s.AddUser(u)
+	}
+	for loginName, password := range f.Passwords {
+		s.passwords[loginName] = password
+	}
+	for token, userID := range f.Tokens {
+		s.tokens[token] = userID
+	}
+	for endpoint, fault := range f.Faults {
+		s.SetFault(endpoint, fault)
+	}
+	return s
+}
+
+// Handler 返回挂载在基础路径下的 http.Handler
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", s.handle(EndpointLogin, false, s.login))
+	mux.HandleFunc("GET /info", s.handle(EndpointInfo, true, s.info))
+	mux.HandleFunc("POST /{$}", s.handle(EndpointCreate, true, s.create))
+	mux.HandleFunc("GET /{$}", s.handle(EndpointList, true, s.list))
+	mux.HandleFunc("POST /batch", s.handle(EndpointBatchGet, true, s.batchGet))
+	mux.HandleFunc("GET /login-name/{loginName}", s.handle(EndpointGetByLoginName, true, s.getByLoginName))
+	mux.HandleFunc("GET /sap-employee-id/{id}", s.handle(EndpointGetBySAP, true, s.getBySAP))
+	mux.HandleFunc("GET /{id}", s.handle(EndpointGet, true, s.get))
+	mux.HandleFunc("PUT /{id}", s.handle(EndpointUpdate, true, s.update))
+	mux.HandleFunc("DELETE /{id}", s.handle(EndpointDelete, true, s.delete))
+
+	root := http.NewServeMux()
+	root.Handle(s.path+"/", http.StripPrefix(s.path, mux))
+	return root
+}
+
+// Start 启动 httptest.Server，供 Go 测试使用，调用方负责 Close
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s.Handler())
+}
+
+// ServiceConfig 返回指向 baseURL（如 httptest.Server.URL）的 user_service 配置，不重试，超时 5s
+// 使用 SystemAuth 时需自行设置 SuperAdminUsername/SuperAdminPassword
+func (s *Server) ServiceConfig(baseURL string) config.BaseServiceConfig {
+	c := config.BaseServiceConfig{
+		Scheme:             "http",
+		Path:               s.path,
+		Timeout:            5 * time.Second,
+		AuthMode:           config.AuthModeToken,
+		TokenRefreshBefore: time.Minute,
+		TokenTTL:           30 * time.Minute,
+		RetryBackoff:       100 * time.Millisecond,
+		RetryBackoffMax:    time.Second,
+		MaxConcurrency:     100,
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return c
+	}
+	c.Scheme = u.Scheme
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		c.Host = u.Host
+		return c
+	}
+	c.Host = host
+	c.Port, _ = strconv.Atoi(port)
+	return c
+}
+
+// AddUser 添加用户，ID 为 0 时自动分配，ID 已存在时覆盖；返回保存后的用户
+func (s *Server) AddUser(u types.User) types.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.saveUser(u)
+}
+
+// User 按 ID 查询当前保存的用户
+func (s *Server) User(id int) (types.User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[id]
+	if !ok {
+		return types.User{}, false
+	}
+	return *u, true
+}
+
+// SetPassword 设置登录密码
+func (s *Server) SetPassword(loginName, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.passwords[loginName] = password
+}
+
+// SetToken 将 token（不含 "Bearer " 前缀）映射到用户
+func (s *Server) SetToken(token string, userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token] = userID
+}
+
+// IssueToken 为用户签发 token（不含 "Bearer " 前缀）：配置了 AccessSecret 时为 JWT，否则为随机字符串
+func (s *Server) IssueToken(userID int) (string, error) {
+	if s.secret != "" {
+		now := time.Now()
+		claims := tokenClaims{
+			TokenPayload: utils.TokenPayload{UserID: userID, Type: utils.TokenTypeAccess},
+			RegisteredClaims: jwt.RegisteredClaims{
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+			},
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.secret))
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := "mock-" + hex.EncodeToString(b)
+	s.SetToken(token, userID)
+	return token, nil
+}
+
+// SetFault 为接口注入故障，endpoint 为 EndpointAll 时对所有接口生效（接口自身的故障优先）
+func (s *Server) SetFault(endpoint string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = &f
+}
+
+// ClearFaults 清除所有注入的故障
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.faults)
+}
+
+// Calls 接口被调用的次数（含注入故障的调用）
+func (s *Server) Calls(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[endpoint]
+}
+
+// tokenClaims 与 utils.ParseAccessToken 解析的 claims 结构一致
+type tokenClaims struct {
+	utils.TokenPayload
+	jwt.RegisteredClaims
+}
+
+// handlerFunc 处理请求，userID 为当前 token 对应的用户（不需要认证的接口为 0）
+type handlerFunc func(r *http.Request, userID int) (any, error)
+
+// handle 统计调用次数、应用注入的故障、校验 token，并按 BaseResponse 结构写响应
+func (s *Server) handle(endpoint string, auth bool, fn handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.calls[endpoint]++
+		fault := s.takeFault(endpoint)
+		s.mu.Unlock()
+
+		if fault != nil {
+			if fault.Latency > 0 {
+				select {
+				case <-time.After(time.Duration(fault.Latency)):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if fault.Status != 0 {
+				code, msg := fault.Code, fault.Msg
+				if code == 0 {
+					code = fault.Status
+				}
+				if msg == "" {
+					msg = "模拟故障"
+				}
+				httpx.WriteJson(w, fault.Status, response.Result{Code: code, Msg: msg})
+				return
+			}
+		}
+
+		var userID int
+		if auth {
+			var err error
+			if userID, err = s.authenticate(r); err != nil {
+				writeError(w, err)
+				return
+			}
+		}
+		data, err := fn(r, userID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		httpx.OkJson(w, response.Result{Code: http.StatusOK, Msg: response.SUCCESS, Data: data})
+	}
+}
+
+// takeFault 取出接口当前生效的故障并扣减次数，调用方需持有 mu
+func (s *Server) takeFault(endpoint string) *Fault {
+	key := endpoint
+	f, ok := s.faults[key]
+	if !ok {
+		key = EndpointAll
+		if f, ok = s.faults[key]; !ok {
+			return nil
+		}
+	}
+	fault := *f
+	if f.Times > 0 {
+		if f.Times--; f.Times == 0 {
+			delete(s.faults, key)
+		}
+	}
+	return &fault
+}
+
+// authenticate 按 Authorization 头解析用户ID：先查固定 token，再按 AccessSecret 校验 JWT
+func (s *Server) authenticate(r *http.Request) (int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return 0, errUnauthorized
+	}
+
+	s.mu.Lock()
+	userID, ok := s.tokens[token]
+	s.mu.Unlock()
+	if ok {
+		return userID, nil
+	}
+	if s.secret == "" {
+		return 0, errUnauthorized
+	}
+	payload, err := utils.ParseAccessToken(token, s.secret)
+	if err != nil {
+		return 0, errUnauthorized
+	}
+	return payload.UserID, nil
+}
+
+// writeError 写错误响应：4xx/5xx 的 code 同时作为 HTTP 状态码，其他 code 返回 200
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*response.Error)
+	if !ok {
+		e = response.NewError(http.StatusInternalServerError, err.Error())
+	}
+	status := http.StatusOK
+	if e.Code >= 400 && e.Code < 600 {
+		status = e.Code
+	}
+	httpx.WriteJson(w, status, response.Result{Code: e.Code, Msg: e.Msg})
+}
+
+// saveUser 保存用户并补全 ID 和时间戳，调用方需持有 mu
+func (s *Server) saveUser(u types.User) *types.User {
+	if u.ID == 0 {
+		u.ID = s.nextID
+	}
+	if u.ID >= s.nextID {
+		s.nextID = u.ID + 1
+	}
+	now := time.Now().Format(time.RFC3339)
+	if u.CreatedAt == "" {
+		u.CreatedAt = now
+	}
+	if u.UpdatedAt == "" {
+		u.UpdatedAt = now
+	}
+	s.users[u.ID] = &u
+	return &u
+}
+
+// findUser 按条件查找第一个用户（按 ID 升序），调用方需持有 mu
+func (s *Server) findUser(match func(u *types.User) bool) *types.User {
+	for _, u := range s.sortedUsers() {
+		if match(u) {
+			return u
+		}
+	}
+	return nil
+}
+
+// sortedUsers 按 ID 升序返回所有用户，调用方需持有 mu
+func (s *Server) sortedUsers() []*types.User {
+	users := make([]*types.User, 0, len(s.users))
+	for _, u := range s.users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// 任务运行状态
+const (
+	RunStatusRunning = "running" // 运行中
+	RunStatusSuccess = "success" // 成功
+	RunStatusFailed  = "failed"  // 失败
+	RunStatusTimeout = "timeout" // 超时
+	// RunStatusDeadLetter 可重试的错误重试耗尽或被停机打断后进入死信，可通过重新投递接口再次执行
+	RunStatusDeadLetter = "dead_letter"
+	// RunStatusRedriven 死信已被重新投递
+	RunStatusRedriven = "redriven"
+	// RunStatusSkipped 并发策略为 forbid 且上一次运行未结束，跳过本次
+	RunStatusSkipped = "skipped"
+	// RunStatusCanceled 并发策略为 replace，被新的运行取消
+	RunStatusCanceled = "canceled"
+)
+
+// CronJobRun 定时任务运行记录
+type CronJobRun struct {
+	ID           int64      `gorm:"primaryKey"`
+	TaskID       int64      `gorm:"index"`                            // 任务ID
+	TaskName     string     `gorm:"type:varchar(100);index;not null"` // 任务名称
+	Handler      string     `gorm:"type:varchar(100);not null"`       // 处理器名称
+	Trigger      string     `gorm:"type:varchar(20);not null"`        // 触发方式: schedule, manual, redrive, catchup
+	Status       string     `gorm:"type:varchar(20);index;not null"`  // 运行状态: running, success, failed, timeout, dead_letter, redriven, skipped, canceled
+	Attempts     int        `gorm:"not null;default:0"`               // 已尝试次数（含首次）
+	RedriveOf    int64      `gorm:"index;not null;default:0"`         // 重新投递来源的死信运行记录ID，0 表示非重新投递
+	ScheduledAt  *time.Time `gorm:"index"`                            // 计划触发时间，仅 schedule、catchup 运行有值
+	StartedAt    time.Time  `gorm:"index;not null"`                   // 开始时间
+	FinishedAt   *time.Time // 结束时间，运行中为 nil
+	DurationMs   int64      `gorm:"not null;default:0"`         // 耗时（毫秒）
+	Error        string     `gorm:"type:text"`                  // 错误信息
+	Instance     string     `gorm:"type:varchar(255);not null"` // 执行实例标识
+	FencingToken int64      `gorm:"not null;default:0"`         // 执行时的 leader fencing token，手动执行和重新投递为 0
+	CreatedAt    time.Time  // 创建时间
+}
+
+func (CronJobRun) TableName() string {
+	return "cron_job_runs"
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// 错过调度（停机、leader 切换期间）后的补偿策略
+const (
+	MisfireSkip     = "skip"      // 跳过错过的调度
+	MisfireFireOnce = "fire_once" // 只补执行一次
+	MisfireFireAll  = "fire_all"  // 逐个补执行错过的调度，最多 MaxCatchup 次
+)
+
+// 运行（含手动执行和重新投递）与上一次运行重叠时的并发策略（参考 Kubernetes CronJob），跨实例生效
+const (
+	ConcurrencyAllow   = "allow"   // 允许并发执行
+	ConcurrencyForbid  = "forbid"  // 上一次运行未结束时跳过本次计划运行并记录，手动执行和重新投递被拒绝
+	ConcurrencyReplace = "replace" // 取消正在执行的运行，改为执行本次
+)
+
+// CronTask 定时任务定义
+type CronTask struct {
+	ID                int64     `gorm:"primaryKey"`
+	Name              string    `gorm:"type:varchar(100);uniqueIndex;not null"`  // 任务名称（唯一）
+	CronExpr          string    `gorm:"type:varchar(100);not null"`              // cron 表达式（标准 5 段，支持 @every/@daily 等描述符）
+	Handler           string    `gorm:"type:varchar(100);not null"`              // 处理器名称，对应 scheduler 中注册的 Handler
+	Enabled           *bool     `gorm:"type:boolean"`                            // 是否启用
+	Timeout           int       `gorm:"not null;default:0"`                      // 单次执行超时时间（秒），0 表示不限制
+	MaxAttempts       int       `gorm:"not null;default:1"`                      // 总尝试次数（含首次），<= 1 表示不重试
+	RetryBackoff      int       `gorm:"not null;default:0"`                      // 首次重试前的等待时间（秒），之后每次翻倍
+	RetryBackoffMax   int       `gorm:"not null;default:0"`                      // 单次重试等待时间上限（秒），0 表示不限制
+	RetryJitter       float64   `gorm:"not null;default:0"`                      // 重试等待时间的随机抖动比例（0~1）
+	RetryOnTimeout    *bool     `gorm:"type:boolean"`                            // 超时是否重试，nil 视为重试
+	MisfirePolicy     string    `gorm:"type:varchar(20);not null;default:skip"`  // 错过调度的补偿策略: skip, fire_once, fire_all
+	MaxCatchup        int       `gorm:"not null;default:0"`                      // fire_all 时最多补执行的次数，0 表示使用调度器默认值
+	ConcurrencyPolicy string    `gorm:"type:varchar(20);not null;default:allow"` // 并发策略: allow, forbid, replace（作用于所有触发方式）
+	Description       string    `gorm:"type:varchar(255)"`                       // 任务描述
+	FencingToken      int64     `gorm:"not null;default:0"`                      // 最近一次执行计划和补偿运行的 leader fencing token，用于拒绝过期 leader 的写入
+	CreatedAt         time.Time // 创建时间
+	UpdatedAt         time.Time // 更新时间
+}
+
+func (CronTask) TableName() string {
+	return "cron_tasks"
+}
+
+// IsEnabled 任务是否启用（nil 视为未启用）
+func (t *CronTask) IsEnabled() bool {
+	return t.Enabled != nil && *t.Enabled
+}
+
+// TimeoutDuration 单次执行超时时间，0 表示不限制
+func (t *CronTask) TimeoutDuration() time.Duration {
+	return time.Duration(t.Timeout) * time.Second
+}
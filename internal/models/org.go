@@ -0,0 +1,15 @@
+package models
+
+// OrgLevels 组织层级字段，需要按组织范围过滤（db.OrgScope）的表嵌入该结构体
+// 取值与 user_service 用户的 org_level1_id ~ org_level9_id 一致，记录数据所属组织的各级祖先
+type OrgLevels struct {
+	OrgLevel1ID *int `gorm:"index"` // 一级组织ID
+	OrgLevel2ID *int `gorm:"index"` // 二级组织ID
+	OrgLevel3ID *int // 三级组织ID
+	OrgLevel4ID *int // 四级组织ID
+	OrgLevel5ID *int // 五级组织ID
+	OrgLevel6ID *int // 六级组织ID
+	OrgLevel7ID *int // 七级组织ID
+	OrgLevel8ID *int // 八级组织ID
+	OrgLevel9ID *int // 九级组织ID
+}
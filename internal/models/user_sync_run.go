@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// 用户同步明细动作
+const (
+	UserSyncActionCreate = "create" // 用户不存在，创建
+	UserSyncActionUpdate = "update" // 用户存在且字段有差异，更新
+	UserSyncActionFailed = "failed" // 查询或写入失败
+)
+
+// UserSyncRun 用户同步运行记录，汇总本次同步的数量
+type UserSyncRun struct {
+	ID         int64      `gorm:"primaryKey"`
+	Source     string     `gorm:"type:varchar(100);index;not null"` // 上游数据源名称
+	DryRun     *bool      `gorm:"type:boolean"`                     // 是否为演练（只对比不写入）
+	Status     string     `gorm:"type:varchar(20);index;not null"`  // 运行状态: running, success, failed
+	Total      int        `gorm:"not null;default:0"`               // 上游记录数
+	Created    int        `gorm:"not null;default:0"`               // 创建数（演练时为待创建数）
+	Updated    int        `gorm:"not null;default:0"`               // 更新数（演练时为待更新数）
+	Skipped    int        `gorm:"not null;default:0"`               // 无差异跳过数
+	Failed     int        `gorm:"not null;default:0"`               // 失败数
+	StartedAt  time.Time  `gorm:"index;not null"`                   // 开始时间
+	FinishedAt *time.Time // 结束时间，运行中为 nil
+	DurationMs int64      `gorm:"not null;default:0"` // 耗时（毫秒）
+	Error      string     `gorm:"type:text"`          // 错误信息（拉取上游数据失败等）
+	CreatedAt  time.Time  // 创建时间
+}
+
+func (UserSyncRun) TableName() string {
+	return "user_sync_runs"
+}
+
+// IsDryRun 是否为演练（nil 视为否）
+func (r *UserSyncRun) IsDryRun() bool {
+	return r.DryRun != nil && *r.DryRun
+}
+
+// UserSyncItem 用户同步明细（差异报告），无差异跳过的用户只计数不记录明细
+type UserSyncItem struct {
+	ID        int64     `gorm:"primaryKey"`
+	RunID     int64     `gorm:"index;not null"`                   // 用户同步运行记录ID
+	LoginName string    `gorm:"type:varchar(100);index;not null"` // 登录名
+	UserID    int       `gorm:"not null;default:0"`               // user_service 用户ID，待创建时为 0
+	Action    string    `gorm:"type:varchar(20);index;not null"`  // 动作: create, update, failed
+	Changes   string    `gorm:"type:text"`                        // 字段差异（JSON），key 为字段名，value 为 {"old": ..., "new": ...}
+	Error     string    `gorm:"type:text"`                        // 错误信息
+	CreatedAt time.Time // 创建时间
+}
+
+func (UserSyncItem) TableName() string {
+	return "user_sync_items"
+}
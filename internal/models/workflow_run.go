@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// NodeStatusPending 工作流节点等待上游完成，其余状态与 CronJobRun 一致（running, success, failed, timeout, skipped, canceled）
+const NodeStatusPending = "pending"
+
+// WorkflowRun 工作流运行记录
+type WorkflowRun struct {
+	ID         int64      `gorm:"primaryKey"`
+	Workflow   string     `gorm:"type:varchar(100);index;not null"` // 工作流名称
+	Status     string     `gorm:"type:varchar(20);index;not null"`  // 运行状态: running, success, failed
+	StartedAt  time.Time  `gorm:"index;not null"`                   // 开始时间
+	FinishedAt *time.Time // 结束时间，运行中为 nil
+	DurationMs int64      `gorm:"not null;default:0"`         // 耗时（毫秒）
+	Error      string     `gorm:"type:text"`                  // 错误信息（失败节点汇总）
+	Instance   string     `gorm:"type:varchar(255);not null"` // 执行实例标识
+	CreatedAt  time.Time  // 创建时间
+}
+
+func (WorkflowRun) TableName() string {
+	return "workflow_runs"
+}
+
+// WorkflowNodeRun 工作流节点运行记录
+type WorkflowNodeRun struct {
+	ID            int64      `gorm:"primaryKey"`
+	WorkflowRunID int64      `gorm:"index;not null"`             // 工作流运行记录ID
+	Node          string     `gorm:"type:varchar(100);not null"` // 节点名称
+	Handler       string     `gorm:"type:varchar(100);not null"` // 处理器名称
+	DependsOn     string     `gorm:"type:varchar(500)"`          // 上游节点名称，逗号分隔
+	Status        string     `gorm:"type:varchar(20);not null"`  // 运行状态: pending, running, success, failed, timeout, skipped, canceled
+	StartedAt     *time.Time // 开始时间，未开始为 nil
+	FinishedAt    *time.Time // 结束时间，未结束为 nil
+	DurationMs    int64      `gorm:"not null;default:0"` // 耗时（毫秒）
+	Error         string     `gorm:"type:text"`          // 错误信息或跳过原因
+	CreatedAt     time.Time  // 创建时间
+}
+
+func (WorkflowNodeRun) TableName() string {
+	return "workflow_node_runs"
+}
@@ -0,0 +1,39 @@
+package request
+
+import (
+	"context"
+	"maps"
+)
+
+// headersKey 请求级请求头在 context 中的 key
+type headersKey struct{}
+
+// routeKey 路由模板在 context 中的 key
+type routeKey struct{}
+
+// WithHeader 在 context 中附加请求头，使用该 context 的 RequestClient 调用都会携带
+// 用于透传请求 ID、来源等请求级元数据
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	headers := maps.Clone(headersFromContext(ctx))
+	if headers == nil {
+		headers = make(map[string]string, 1)
+	}
+	headers[key] = value
+	return context.WithValue(ctx, headersKey{}, headers)
+}
+
+func headersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(headersKey{}).(map[string]string)
+	return headers
+}
+
+// WithRoute 指定本次调用的路由模板（如 /login-name/{login_name}），用作指标和日志的 route 标签
+// 未指定时由 URL 推导，只替换纯数字的路径段，路径中含登录名等非数字参数时应指定
+func WithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+func routeFromContext(ctx context.Context) string {
+	route, _ := ctx.Value(routeKey{}).(string)
+	return route
+}
@@ -0,0 +1,43 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go-zero-template/internal/types"
+)
+
+// Response 上游响应：HTTP 状态码、响应头和解析后的 BaseResponse
+type Response[T any] struct {
+	StatusCode int
+	Header     http.Header
+	Body       types.BaseResponse[T]
+}
+
+// Do 发送请求并将响应体直接解析为 BaseResponse[T]，重试、熔断和并发限制同 RequestClient.Request
+// 非 2xx 或 BaseResponse.code 为失败时返回 *types.ResponseError（HTTPStatus 为上游 HTTP 状态码），成功解析的响应一并返回
+func Do[T any](ctx context.Context, r *RequestClient, method string, url string, body any, headers map[string]string) (*Response[T], error) {
+	raw, err := r.doRaw(ctx, method, url, body, headers)
+	if raw == nil {
+		return nil, err
+	}
+
+	resp := &Response[T]{
+		StatusCode: raw.StatusCode,
+		Header:     raw.Header,
+	}
+	if err != nil {
+		return resp, err
+	}
+	if len(raw.Body) > 0 {
+		if err := json.Unmarshal(raw.Body, &resp.Body); err != nil {
+			return resp, fmt.Errorf("解析响应失败: %w", err)
+		}
+	}
+	if !resp.Body.Ok() {
+		return resp, &types.ResponseError{Code: resp.Body.Code, Msg: resp.Body.Msg, HTTPStatus: raw.StatusCode}
+	}
+	return resp, nil
+}
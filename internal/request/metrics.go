@@ -0,0 +1,129 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-zero-template/internal/tracing"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// 下游请求指标，经 DevServer 的 /metrics 暴露（DevServer.EnableMetrics 默认开启）
+// route 为路由模板（如 /{id}），避免按 ID、登录名等产生大量标签值
+var (
+	metricRequestDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: "downstream",
+		Subsystem: "requests",
+		Name:      "duration_ms",
+		Help:      "downstream requests duration(ms).",
+		Labels:    []string{"service", "method", "route", "status"},
+		Buckets:   []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	})
+	metricRequestTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "downstream",
+		Subsystem: "requests",
+		Name:      "total",
+		Help:      "downstream requests count.",
+		Labels:    []string{"service", "method", "route", "status"},
+	})
+)
+
+// 未收到上游响应时的 status 标签值
+const (
+	labelUnavailable = "unavailable" // 熔断中或并发请求数已达上限，未发送请求
+	labelCanceled    = "canceled"    // 调用方取消或超时
+	labelError       = "error"       // 网络错误等
+)
+
+// observe 记录一次调用（含重试）的指标和 logx 日志，上游失败时额外写入 Writer
+func (r *RequestClient) observe(ctx context.Context, method, route string, raw *rawResponse, err error, duration time.Duration) {
+	status := statusLabel(raw, err)
+	metricRequestDuration.Observe(duration.Milliseconds(), r.name, method, route, status)
+	metricRequestTotal.Inc(r.name, method, route, status)
+
+	fields := []logx.LogField{
+		logx.Field("service", r.name),
+		logx.Field("method", method),
+		logx.Field("route", route),
+		logx.Field("status", status),
+	}
+	if err != nil {
+		fields = append(fields, logx.Field("error", err.Error()))
+	}
+	// 与熔断器的判断一致：4xx（429 除外）和调用方取消不视为上游失败，如按登录名查询返回 404
+	if acceptable(statusOf(raw), err) {
+		logx.WithContext(ctx).WithDuration(duration).Infow("下游请求", fields...)
+		return
+	}
+	logx.WithContext(ctx).WithDuration(duration).Errorw("下游请求失败", fields...)
+
+	if r.writer == nil {
+		return
+	}
+	r.writer.Error("下游请求失败", r.failureFields(ctx, method, route, status, err, duration)...)
+}
+
+// failureFields 上游失败时写入 Writer 的字段，trace 为 Request.<服务名>，trace_id、span_id 为本次调用的 client span
+func (r *RequestClient) failureFields(ctx context.Context, method, route, status string, err error, duration time.Duration) []writer.FieldT {
+	return []writer.FieldT{
+		writer.Field("log_type", "system"),
+		writer.Field("trace", "Request."+r.name),
+		tracing.TraceIDField(ctx),
+		tracing.SpanIDField(ctx),
+		writer.Field("username", "system"),
+		writer.Field("service", r.name),
+		writer.Field("method", method),
+		writer.Field("route", route),
+		writer.Field("status", status),
+		writer.Field("duration_ms", duration.Milliseconds()),
+		writer.Field("error", err.Error()),
+	}
+}
+
+// statusLabel 指标的 status 标签：收到响应时为 HTTP 状态码，否则区分未发送、取消和其他错误
+func statusLabel(raw *rawResponse, err error) string {
+	switch {
+	case raw != nil:
+		return strconv.Itoa(raw.StatusCode)
+	case errors.Is(err, ErrServiceUnavailable):
+		return labelUnavailable
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return labelCanceled
+	default:
+		return labelError
+	}
+}
+
+// routeTemplate 未通过 WithRoute 指定路由模板时，由 URL 推导：去掉服务基础路径和 query，纯数字的路径段替换为 {id}
+func routeTemplate(rawURL, basePath string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "unknown"
+	}
+	path := u.Path
+	if basePath != "" && basePath != "/" {
+		if rest, ok := strings.CutPrefix(path, strings.TrimSuffix(basePath, "/")); ok && (rest == "" || rest[0] == '/') {
+			path = rest
+		}
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		if _, err := strconv.ParseInt(seg, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+	if path = strings.Join(segments, "/"); path == "" {
+		path = "/"
+	}
+	return path
+}
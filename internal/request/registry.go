@@ -0,0 +1,72 @@
+package request
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"go-zero-template/internal/config"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// Registry 下游服务客户端注册表，按服务名创建并复用 RequestClient
+type Registry struct {
+	services config.ServicesConfig
+	writer   *writer.MultiWriter
+
+	mu      sync.Mutex
+	clients map[string]*RequestClient
+}
+
+func NewRegistry(services config.ServicesConfig, w *writer.MultiWriter) *Registry {
+	return &Registry{
+		services: services,
+		writer:   w,
+		clients:  make(map[string]*RequestClient),
+	}
+}
+
+// Client 返回服务名对应的客户端，服务未在 ServicesConfig 中配置时返回错误
+func (r *Registry) Client(name string) (*RequestClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client, ok := r.clients[name]; ok {
+		return client, nil
+	}
+	c, ok := r.services[name]
+	if !ok {
+		return nil, fmt.Errorf("下游服务未配置: %s", name)
+	}
+	client, err := NewRequestClient(name, c, r.writer)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[name] = client
+	return client, nil
+}
+
+// MustClient 返回服务名对应的客户端，服务未配置时 panic
+func (r *Registry) MustClient(name string) *RequestClient {
+	client, err := r.Client(name)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// UserService 返回 user_service 客户端，未配置 user_service 时 panic
+func (r *Registry) UserService() *UserServiceClient {
+	return &UserServiceClient{RequestClient: r.MustClient(config.UserServiceName)}
+}
+
+// Names 已配置的服务名，按名称排序
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.services))
+	for name := range r.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
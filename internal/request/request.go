@@ -2,53 +2,151 @@ package request
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"go-zero-template/internal/config"
+	"go-zero-template/internal/types"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
+
+	writer "github.com/zhengliu92/pg-log-writter"
 )
 
+// RequestClient 单个下游服务的 HTTP 客户端，一般通过 Registry 按服务名获取
 type RequestClient struct {
-	client      *http.Client
-	services    *config.ServicesConfig
-	userBaseURL string
+	name       string
+	config     config.BaseServiceConfig
+	client     *http.Client
+	baseURL    string
+	tokens     *tokenProvider
+	resilience *resilience
+	writer     *writer.MultiWriter
 }
 
-func NewRequestClient(services *config.ServicesConfig) *RequestClient {
-	return &RequestClient{
+// NewRequestClient 创建下游服务客户端，Scheme 为 https 时按 TLS 配置加载 CA 和客户端证书，证书无效时返回错误
+// w 用于记录失败的调用，为 nil 时只输出到 logx
+func NewRequestClient(name string, c config.BaseServiceConfig, w *writer.MultiWriter) (*RequestClient, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.Scheme == "https" {
+		tlsConfig, err := buildTLSConfig(name, c.TLS)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	r := &RequestClient{
+		name:   name,
+		config: c,
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   c.Timeout,
+			Transport: transport,
 		},
-		services:    services,
-		userBaseURL: fmt.Sprintf("http://%s:%d%s", services.UserService.Host, services.UserService.Port, services.UserService.Path),
+		baseURL: buildBaseURL(c),
+		writer:  w,
 	}
+	r.tokens = newTokenProvider(r, c)
+	r.resilience = newResilience(c)
+	return r, nil
 }
 
-func (r *RequestClient) Request(method string, url string, body any, headers map[string]string) (any, error) {
-	var reqBody io.Reader
+// Name 服务名
+func (r *RequestClient) Name() string {
+	return r.name
+}
+
+// BaseURL 服务的基础地址，如 http://localhost:8000/api/v1/user
+func (r *RequestClient) BaseURL() string {
+	return r.baseURL
+}
+
+func buildBaseURL(c config.BaseServiceConfig) string {
+	host := c.Host
+	if c.Port > 0 {
+		host = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	}
+	return fmt.Sprintf("%s://%s%s", c.Scheme, host, c.Path)
+}
+
+// Request 发送请求并将 JSON 响应解析为 any，响应结构已知时优先使用 Do
+// ctx 的截止时间和取消会传递到上游调用，ctx 中通过 WithHeader 附加的请求头会一并发送，headers 中的同名请求头优先
+// 请求经过按服务名隔离的并发限制和熔断器，幂等方法在网络错误或 429/502/503/504 时按配置重试
+// 非 2xx 响应返回 *types.ResponseError；每次调用记录 Prometheus 指标和 logx 日志，上游失败（网络错误、超时、5xx、429）时写入 Writer
+func (r *RequestClient) Request(ctx context.Context, method string, url string, body any, headers map[string]string) (any, error) {
+	raw, err := r.doRaw(ctx, method, url, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if len(raw.Body) > 0 {
+		if err := json.Unmarshal(raw.Body, &result); err != nil {
+			return nil, fmt.Errorf("解析响应失败: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// rawResponse 一次请求的原始响应
+type rawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// doRaw 序列化请求体并在重试、熔断和并发限制下发送请求，整个调用（含重试）对应一个 client span，并记录耗时和结果
+func (r *RequestClient) doRaw(ctx context.Context, method string, url string, body any, headers map[string]string) (*rawResponse, error) {
+	var payload []byte
 	if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("序列化请求体失败: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+		payload = jsonData
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	route := routeFromContext(ctx)
+	if route == "" {
+		route = routeTemplate(url, r.config.Path)
+	}
+	ctx, span := r.startSpan(ctx, method, url, route)
+	start := time.Now()
+	raw, err := r.do(ctx, method, url, func() (*rawResponse, error) {
+		return r.send(ctx, method, url, payload, headers)
+	})
+	endSpan(span, raw, err)
+	r.observe(ctx, method, route, raw, err, time.Since(start))
+	return raw, err
+}
+
+// send 发送一次请求；未收到响应时返回 nil，非 2xx 时同时返回响应和 *types.ResponseError
+func (r *RequestClient) send(ctx context.Context, method string, url string, payload []byte, headers map[string]string) (*rawResponse, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
 
-	if body != nil {
+	if payload != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// 设置自定义 headers
+	// 设置 context 中的请求头和自定义 headers
+	for key, value := range headersFromContext(ctx) {
+		req.Header.Set(key, value)
+	}
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
+	injectTrace(ctx, req.Header)
 
 	resp, err := r.client.Do(req)
 	if err != nil {
@@ -56,21 +154,25 @@ func (r *RequestClient) Request(method string, url string, body any, headers map
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %w", err)
+	raw := &rawResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+	}
+	if raw.Body, err = io.ReadAll(resp.Body); err != nil {
+		return raw, fmt.Errorf("读取响应失败: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("请求失败: status=%d, body=%s", resp.StatusCode, string(respBody))
+		return raw, statusError(resp.StatusCode, raw.Body)
 	}
+	return raw, nil
+}
 
-	var result any
-	if len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, &result); err != nil {
-			return nil, fmt.Errorf("解析响应失败: %w", err)
-		}
+// statusError 将非 2xx 响应转为 *types.ResponseError，便于调用方用 errors.As 区分 404 与其他错误
+// 响应体为 BaseResponse 且 code 非 0 时优先使用其 code、msg，否则使用 HTTP 状态码和原始响应体
+func statusError(statusCode int, body []byte) *types.ResponseError {
+	if resp, err := types.ParseBaseResponse[any](body); err == nil && resp.Code != 0 {
+		return &types.ResponseError{Code: resp.Code, Msg: resp.Msg, HTTPStatus: statusCode}
 	}
-
-	return result, nil
+	return &types.ResponseError{Code: statusCode, Msg: string(body), HTTPStatus: statusCode}
 }
@@ -0,0 +1,154 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/response"
+
+	"github.com/zeromicro/go-zero/core/breaker"
+)
+
+// ErrServiceUnavailable 上游服务熔断中或并发请求数已达上限，调用方可用 errors.Is 判断，经 response.Response 返回 503
+var ErrServiceUnavailable = response.NewError(http.StatusServiceUnavailable, "上游服务暂不可用")
+
+// resilience 上游调用的重试、熔断和并发限制，熔断器和并发限制按服务名隔离，未命名的客户端按上游 host 隔离
+type resilience struct {
+	config config.BaseServiceConfig
+
+	mu        sync.Mutex
+	bulkheads map[string]chan struct{}
+}
+
+func newResilience(c config.BaseServiceConfig) *resilience {
+	return &resilience{
+		config:    c,
+		bulkheads: make(map[string]chan struct{}),
+	}
+}
+
+// do 执行请求，幂等方法在可重试的错误后按指数退避重试，最多重试 MaxRetries 次
+func (r *RequestClient) do(ctx context.Context, method, rawURL string, send func() (*rawResponse, error)) (*rawResponse, error) {
+	// 同一 host 上的不同服务互不影响熔断状态
+	key := r.name
+	if key == "" {
+		key = rawURL
+		if u, err := url.Parse(rawURL); err == nil {
+			key = u.Host
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		raw, err := r.resilience.attempt(ctx, key, send)
+		if err == nil || attempt >= r.resilience.config.MaxRetries || !retryable(ctx, method, statusOf(raw), err) {
+			return raw, err
+		}
+
+		timer := time.NewTimer(r.resilience.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt 在并发限制和熔断器保护下发送一次请求，key 为服务名或上游 host
+func (r *resilience) attempt(ctx context.Context, key string, send func() (*rawResponse, error)) (*rawResponse, error) {
+	release, ok := r.acquire(key)
+	if !ok {
+		return nil, fmt.Errorf("%s 并发请求数已达上限: %w", key, ErrServiceUnavailable)
+	}
+	defer release()
+
+	var raw *rawResponse
+	err := breaker.GetBreaker("request:"+key).DoWithAcceptableCtx(ctx, func() error {
+		var err error
+		raw, err = send()
+		return err
+	}, func(err error) bool {
+		return acceptable(statusOf(raw), err)
+	})
+	if errors.Is(err, breaker.ErrServiceUnavailable) {
+		return nil, fmt.Errorf("%s 熔断中: %w", key, ErrServiceUnavailable)
+	}
+	return raw, err
+}
+
+// statusOf 响应的 HTTP 状态码，未收到响应时为 0
+func statusOf(raw *rawResponse) int {
+	if raw == nil {
+		return 0
+	}
+	return raw.StatusCode
+}
+
+// acquire 占用 key 的一个并发名额，已满时立即返回 false；MaxConcurrency <= 0 表示不限制
+func (r *resilience) acquire(key string) (func(), bool) {
+	if r.config.MaxConcurrency <= 0 {
+		return func() {}, true
+	}
+
+	r.mu.Lock()
+	slots, ok := r.bulkheads[key]
+	if !ok {
+		slots = make(chan struct{}, r.config.MaxConcurrency)
+		r.bulkheads[key] = slots
+	}
+	r.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, true
+	default:
+		return nil, false
+	}
+}
+
+// backoff 第 attempt 次重试前的等待时间：RetryBackoff * 2^attempt，不超过 RetryBackoffMax，带 ±20% 抖动
+// RetryBackoff <= 0 时立即重试
+func (r *resilience) backoff(attempt int) time.Duration {
+	if r.config.RetryBackoff <= 0 {
+		return 0
+	}
+	d := r.config.RetryBackoff << attempt
+	if d <= 0 || (r.config.RetryBackoffMax > 0 && d > r.config.RetryBackoffMax) {
+		d = r.config.RetryBackoffMax
+	}
+	return time.Duration(float64(d) * (0.8 + 0.4*rand.Float64()))
+}
+
+// acceptable 熔断器是否将本次结果视为成功：4xx（429 除外）为调用方问题，不计入上游失败；调用方取消也不计入
+func acceptable(status int, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return true
+	}
+	return status >= 400 && status < 500 && status != http.StatusTooManyRequests
+}
+
+// retryable 是否可以重试：仅幂等方法，且为网络错误（含单次请求超过 http.Client.Timeout）或 429/502/503/504
+// 调用方 ctx 已取消或超时时不重试
+func retryable(ctx context.Context, method string, status int, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	// http.Client.Timeout 超时的错误同样满足 errors.Is(err, context.DeadlineExceeded)，此时调用方 ctx 仍有效，按网络错误重试
+	if ctx.Err() != nil || errors.Is(err, ErrServiceUnavailable) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	switch status {
+	case 0, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     time.Duration
+		max      time.Duration
+		attempt  int
+		min, top time.Duration
+	}{
+		{"首次重试", 100 * time.Millisecond, time.Second, 0, 80 * time.Millisecond, 120 * time.Millisecond},
+		{"指数增长", 100 * time.Millisecond, time.Second, 2, 320 * time.Millisecond, 480 * time.Millisecond},
+		{"不超过上限", 100 * time.Millisecond, time.Second, 5, 800 * time.Millisecond, 1200 * time.Millisecond},
+		{"没有上限", 100 * time.Millisecond, 0, 5, 2560 * time.Millisecond, 3840 * time.Millisecond},
+		{"基础等待为 0 时立即重试", 0, time.Second, 3, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newResilience(config.BaseServiceConfig{RetryBackoff: tt.base, RetryBackoffMax: tt.max})
+			if got := r.backoff(tt.attempt); got < tt.min || got > tt.top {
+				t.Fatalf("backoff(%d) = %v, want [%v, %v]", tt.attempt, got, tt.min, tt.top)
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	background := context.Background()
+	clientTimeout := &timeoutErr{}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{"GET 网络错误", background, http.MethodGet, 0, errors.New("connection refused"), true},
+		{"GET 503", background, http.MethodGet, http.StatusServiceUnavailable, errors.New("503"), true},
+		{"GET 500 不重试", background, http.MethodGet, http.StatusInternalServerError, errors.New("500"), false},
+		{"POST 不重试", background, http.MethodPost, 0, errors.New("connection refused"), false},
+		{"单次请求超时", background, http.MethodGet, 0, clientTimeout, true},
+		{"POST 单次请求超时不重试", background, http.MethodPost, 0, clientTimeout, false},
+		{"调用方 ctx 已结束", canceled, http.MethodGet, 0, clientTimeout, false},
+		{"熔断或并发已满", background, http.MethodGet, 0, ErrServiceUnavailable, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.ctx, tt.method, tt.status, tt.err); got != tt.want {
+				t.Fatalf("retryable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// timeoutErr 与 http.Client.Timeout 超时的错误一样满足 errors.Is(err, context.DeadlineExceeded)
+type timeoutErr struct{}
+
+func (*timeoutErr) Error() string        { return "Client.Timeout exceeded" }
+func (*timeoutErr) Is(target error) bool { return target == context.DeadlineExceeded }
+
+func TestRetryClientTimeout(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 第一次请求超过客户端超时，之后正常返回
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		okHandler(w, r)
+	}))
+	defer ts.Close()
+
+	newClient := func(name string) *RequestClient {
+		client, err := NewRequestClient(name, config.BaseServiceConfig{
+			Timeout:    100 * time.Millisecond,
+			AuthMode:   config.AuthModeNone,
+			MaxRetries: 1,
+		}, nil)
+		if err != nil {
+			t.Fatalf("NewRequestClient: %v", err)
+		}
+		return client
+	}
+
+	t.Run("幂等请求单次超时后重试", func(t *testing.T) {
+		calls.Store(0)
+		if _, err := newClient("timeout_get").Request(context.Background(), http.MethodGet, ts.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("请求失败: %v", err)
+		}
+		if got := calls.Load(); got != 2 {
+			t.Fatalf("请求次数 = %d, want 2", got)
+		}
+	})
+
+	t.Run("非幂等请求不重试", func(t *testing.T) {
+		calls.Store(0)
+		if _, err := newClient("timeout_post").Request(context.Background(), http.MethodPost, ts.URL+"/ping", nil, nil); err == nil {
+			t.Fatal("超时的 POST 请求应失败")
+		}
+		if got := calls.Load(); got != 1 {
+			t.Fatalf("请求次数 = %d, want 1", got)
+		}
+	})
+
+	t.Run("调用方超时不重试", func(t *testing.T) {
+		calls.Store(0)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := newClient("timeout_caller").Request(ctx, http.MethodGet, ts.URL+"/ping", nil, nil); err == nil {
+			t.Fatal("调用方超时的请求应失败")
+		}
+		if got := calls.Load(); got != 1 {
+			t.Fatalf("请求次数 = %d, want 1", got)
+		}
+	})
+}
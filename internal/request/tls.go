@@ -0,0 +1,45 @@
+package request
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"go-zero-template/internal/config"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// buildTLSConfig 按配置构造 TLS 配置：CAFile 为空时使用系统根证书，CertFile/KeyFile 用于 mTLS
+func buildTLSConfig(name string, c config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.InsecureSkipVerify {
+		logx.Errorf("下游服务 %s 已关闭 TLS 证书校验（TLS.InsecureSkipVerify），仅限本地开发使用", name)
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取服务 %s 的 CA 证书失败: %w", name, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("服务 %s 的 CA 证书无效: %s", name, c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载服务 %s 的客户端证书失败: %w", name, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
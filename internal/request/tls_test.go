@@ -0,0 +1,177 @@
+package request
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+)
+
+// testCert 测试用证书，parent 为 nil 时为自签名 CA
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成私钥失败: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("签发证书失败: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("解析证书失败: %v", err)
+	}
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// writePEM 将证书（及私钥）写入临时目录，返回证书和私钥文件路径
+func (c *testCert) writePEM(t *testing.T, name string) (certFile, keyFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, name+".crt")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600); err != nil {
+		t.Fatalf("写入证书失败: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("序列化私钥失败: %v", err)
+	}
+	keyFile = filepath.Join(dir, name+".key")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("写入私钥失败: %v", err)
+	}
+	return certFile, keyFile
+}
+
+// writeServerCA 将 httptest 的自签名服务端证书写入文件，作为校验服务端证书的 CA
+func writeServerCA(t *testing.T, ts *httptest.Server) string {
+	t.Helper()
+	caFile := filepath.Join(t.TempDir(), "server-ca.crt")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("写入 CA 证书失败: %v", err)
+	}
+	return caFile
+}
+
+func newTLSTestClient(t *testing.T, c config.TLSConfig) *RequestClient {
+	t.Helper()
+	client, err := NewRequestClient("tls_test", config.BaseServiceConfig{
+		Scheme:   "https",
+		Timeout:  5 * time.Second,
+		TLS:      c,
+		AuthMode: config.AuthModeNone,
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewRequestClient: %v", err)
+	}
+	return client
+}
+
+func okHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"code":0,"msg":"ok"}`))
+}
+
+func TestTLSCustomCA(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(okHandler))
+	defer ts.Close()
+
+	t.Run("服务端证书由配置的 CA 签发", func(t *testing.T) {
+		client := newTLSTestClient(t, config.TLSConfig{CAFile: writeServerCA(t, ts)})
+		if _, err := client.Request(context.Background(), http.MethodGet, ts.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("请求失败: %v", err)
+		}
+	})
+
+	t.Run("服务端证书不是配置的 CA 签发", func(t *testing.T) {
+		caFile, _ := newTestCert(t, "other-ca", nil).writePEM(t, "other-ca")
+		client := newTLSTestClient(t, config.TLSConfig{CAFile: caFile})
+		if _, err := client.Request(context.Background(), http.MethodGet, ts.URL+"/ping", nil, nil); err == nil {
+			t.Fatal("CA 不匹配时请求应失败")
+		}
+	})
+
+	t.Run("无效的 CA 文件", func(t *testing.T) {
+		caFile := filepath.Join(t.TempDir(), "invalid.crt")
+		if err := os.WriteFile(caFile, []byte("not a pem"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewRequestClient("tls_test", config.BaseServiceConfig{Scheme: "https", TLS: config.TLSConfig{CAFile: caFile}}, nil); err == nil {
+			t.Fatal("CA 文件无效时 NewRequestClient 应返回错误")
+		}
+	})
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	clientCA := newTestCert(t, "client-ca", nil)
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCA.cert)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(okHandler))
+	ts.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+	caFile := writeServerCA(t, ts)
+
+	t.Run("携带客户端证书", func(t *testing.T) {
+		certFile, keyFile := newTestCert(t, "client", clientCA).writePEM(t, "client")
+		client := newTLSTestClient(t, config.TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+		if _, err := client.Request(context.Background(), http.MethodGet, ts.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("请求失败: %v", err)
+		}
+	})
+
+	t.Run("未携带客户端证书", func(t *testing.T) {
+		client := newTLSTestClient(t, config.TLSConfig{CAFile: caFile})
+		if _, err := client.Request(context.Background(), http.MethodGet, ts.URL+"/ping", nil, nil); err == nil {
+			t.Fatal("服务端要求客户端证书时请求应失败")
+		}
+	})
+
+	t.Run("客户端证书不是服务端信任的 CA 签发", func(t *testing.T) {
+		certFile, keyFile := newTestCert(t, "client", newTestCert(t, "other-ca", nil)).writePEM(t, "client")
+		client := newTLSTestClient(t, config.TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+		if _, err := client.Request(context.Background(), http.MethodGet, ts.URL+"/ping", nil, nil); err == nil {
+			t.Fatal("客户端证书不受信任时请求应失败")
+		}
+	})
+}
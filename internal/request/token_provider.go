@@ -0,0 +1,123 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/types"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// SystemAuth 作为 token 参数传入 RequestClient 方法时，使用系统账号（超级管理员）的 token 调用下游服务
+// 供定时任务等没有用户请求上下文的场景使用，token 过期前自动刷新，遇到 401 时重新登录并重试一次
+const SystemAuth = "system"
+
+// tokenProvider 系统账号 token 提供者：登录 user_service 并缓存 access token
+type tokenProvider struct {
+	client *RequestClient
+	config config.BaseServiceConfig
+
+	mu        sync.Mutex
+	token     string // 含 "Bearer " 前缀
+	expiresAt time.Time
+}
+
+func newTokenProvider(client *RequestClient, c config.BaseServiceConfig) *tokenProvider {
+	return &tokenProvider{
+		client: client,
+		config: c,
+	}
+}
+
+// Token 返回缓存的系统 token，距过期不足 TokenRefreshBefore 时重新登录；并发调用只登录一次
+func (p *tokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if p.token != "" && time.Now().Add(p.config.TokenRefreshBefore).Before(p.expiresAt) {
+		return p.token, nil
+	}
+	if p.config.SuperAdminUsername == "" || p.config.SuperAdminPassword == "" {
+		return "", fmt.Errorf("未配置 %s 系统账号", p.client.name)
+	}
+
+	// token 由所有调用方共享，登录不随当前调用方取消，由 http.Client 超时兜底
+	resp, err := p.client.Login(context.WithoutCancel(ctx), p.config.SuperAdminUsername, p.config.SuperAdminPassword)
+	if err != nil {
+		return "", fmt.Errorf("登录 %s 失败: %w", p.client.name, err)
+	}
+	p.token = "Bearer " + resp.Token
+	p.expiresAt = tokenExpiry(resp.Token, p.config.TokenTTL)
+	return p.token, nil
+}
+
+// Invalidate 丢弃缓存的 token（被 user_service 拒绝时调用），token 已被其他调用刷新时忽略
+func (p *tokenProvider) Invalidate(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token == token {
+		p.token = ""
+	}
+}
+
+// tokenExpiry 读取 token 的 exp 声明作为过期时间（不校验签名），没有 exp 时按 ttl 计算
+func tokenExpiry(token string, ttl time.Duration) time.Time {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err == nil && claims.ExpiresAt != nil {
+		return claims.ExpiresAt.Time
+	}
+	return time.Now().Add(ttl)
+}
+
+// doWithToken 按服务的认证方式调用 Do：AuthMode 为 none 时不携带 token
+// token 为 SystemAuth 时使用系统 token，被拒绝（401）时刷新 token 并重试一次
+func doWithToken[T any](ctx context.Context, r *RequestClient, method, url string, body any, token string) (*Response[T], error) {
+	if r.config.AuthMode == config.AuthModeNone {
+		return Do[T](ctx, r, method, url, body, nil)
+	}
+	if token != SystemAuth {
+		return Do[T](ctx, r, method, url, body, map[string]string{"Authorization": token})
+	}
+
+	auth, err := r.tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := Do[T](ctx, r, method, url, body, map[string]string{"Authorization": auth})
+	if !isUnauthorized(err) {
+		return resp, err
+	}
+
+	r.tokens.Invalidate(auth)
+	if auth, err = r.tokens.Token(ctx); err != nil {
+		return nil, err
+	}
+	return Do[T](ctx, r, method, url, body, map[string]string{"Authorization": auth})
+}
+
+// DoSystem 按服务的认证方式调用 Do：AuthMode 为 none 时不携带 token，为 token 时使用本服务系统账号的 token
+// 供定时任务等没有调用方 token 的场景调用 user_service 以外的服务
+func DoSystem[T any](ctx context.Context, r *RequestClient, method, url string, body any) (*Response[T], error) {
+	return doWithToken[T](ctx, r, method, url, body, SystemAuth)
+}
+
+// isUnauthorized 判断 token 是否被拒绝：HTTP 401 或 BaseResponse.code 为 401
+func isUnauthorized(err error) bool {
+	var respErr *types.ResponseError
+	return errors.As(err, &respErr) && respErr.Code == http.StatusUnauthorized
+}
+
+// SystemToken 返回系统账号的 token（含 "Bearer " 前缀），缓存并在过期前自动刷新
+// 调用 RequestClient 方法时优先传入 SystemAuth，可在 token 被拒绝时自动重新登录
+func (r *RequestClient) SystemToken(ctx context.Context) (string, error) {
+	return r.tokens.Token(ctx)
+}
@@ -0,0 +1,47 @@
+package request
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zeromicro/go-zero/core/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// startSpan 为一次调用（含重试）创建 client span，ctx 中没有 span 时（如定时任务）作为根 span
+// 导出方式由 RestConf.Telemetry 配置，未配置 Endpoint 时 span 只在进程内传播，不导出
+func (r *RequestClient) startSpan(ctx context.Context, method, rawURL, route string) (context.Context, oteltrace.Span) {
+	return trace.TracerFromContext(ctx).Start(ctx, method+" "+route,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			semconv.PeerServiceKey.String(r.name),
+			semconv.HTTPMethodKey.String(method),
+			semconv.HTTPURLKey.String(rawURL),
+			semconv.HTTPRouteKey.String(route),
+		),
+	)
+}
+
+// endSpan 记录调用结果并结束 span：收到响应时按 HTTP 状态码设置状态（client span 的 4xx、5xx 为错误），否则记录错误
+func endSpan(span oteltrace.Span, raw *rawResponse, err error) {
+	defer span.End()
+	if raw != nil {
+		span.SetAttributes(attribute.Int(string(semconv.HTTPStatusCodeKey), raw.StatusCode))
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(raw.StatusCode, oteltrace.SpanKindClient))
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// injectTrace 将 ctx 中的 span 以 traceparent 等请求头传递给上游
+func injectTrace(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
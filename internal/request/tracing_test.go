@@ -0,0 +1,136 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// useSpanRecorder 将全局 TracerProvider 替换为记录 span 的 provider，测试结束后恢复
+func useSpanRecorder(t *testing.T) (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+		_ = provider.Shutdown(context.Background())
+	})
+	return recorder, provider
+}
+
+func TestRequestSpan(t *testing.T) {
+	recorder, provider := useSpanRecorder(t)
+
+	var traceparent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/user/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":500,"msg":"error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":0,"msg":"ok"}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewRequestClient("tracing_test", config.BaseServiceConfig{
+		Scheme:   "http",
+		Path:     "/api/v1/user",
+		Timeout:  5 * time.Second,
+		AuthMode: config.AuthModeNone,
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewRequestClient: %v", err)
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	url := ts.URL + "/api/v1/user/42"
+	if _, err := client.Request(ctx, http.MethodGet, url, nil, nil); err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 || spans[0].Name() != "GET /{id}" {
+		t.Fatalf("spans = %d, 第一个 span 名称 = %q", len(spans), spans[0].Name())
+	}
+	span := spans[0]
+	if span.SpanKind() != oteltrace.SpanKindClient {
+		t.Errorf("span kind = %v, want client", span.SpanKind())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("client span 的父 span = %v, want %v", span.Parent().SpanID(), parent.SpanContext().SpanID())
+	}
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	for key, want := range map[attribute.Key]string{
+		semconv.PeerServiceKey: "tracing_test",
+		semconv.HTTPMethodKey:  http.MethodGet,
+		semconv.HTTPURLKey:     url,
+		semconv.HTTPRouteKey:   "/{id}",
+	} {
+		if got := attrs[key].AsString(); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if got := attrs[semconv.HTTPStatusCodeKey].AsInt64(); got != http.StatusOK {
+		t.Errorf("%s = %d, want 200", semconv.HTTPStatusCodeKey, got)
+	}
+
+	sc := span.SpanContext()
+	if want := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"; traceparent != want {
+		t.Errorf("traceparent = %q, want %q", traceparent, want)
+	}
+
+	// 失败日志的 trace 为 Module.Method，trace_id、span_id 为 client span
+	spanCtx, clientSpan := client.startSpan(ctx, http.MethodGet, url, "/{id}")
+	fields := make(map[string]any)
+	for _, f := range client.failureFields(spanCtx, http.MethodGet, "/{id}", "500", errors.New("error"), time.Millisecond) {
+		fields[f.Key] = f.Value
+	}
+	clientSpan.End()
+	for key, want := range map[string]string{
+		"trace":    "Request.tracing_test",
+		"trace_id": clientSpan.SpanContext().TraceID().String(),
+		"span_id":  clientSpan.SpanContext().SpanID().String(),
+	} {
+		if got := fields[key]; got != want {
+			t.Errorf("日志字段 %s = %v, want %q", key, got, want)
+		}
+	}
+
+	// 上游返回 5xx 时 client span 状态为错误
+	if _, err := client.Request(context.Background(), http.MethodGet, ts.URL+"/api/v1/user/fail", nil, nil); err == nil {
+		t.Fatal("上游返回 500 时应返回错误")
+	}
+	spans = recorder.Ended()
+	failed := spans[len(spans)-1]
+	if failed.Status().Code != codes.Error {
+		t.Errorf("5xx span status = %v, want error", failed.Status().Code)
+	}
+	if failed.Parent().IsValid() {
+		t.Errorf("ctx 中没有 span 时应为根 span，父 span = %v", failed.Parent().SpanID())
+	}
+}
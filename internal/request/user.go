@@ -1,11 +1,20 @@
 package request
 
 import (
+	"context"
 	"fmt"
 	"go-zero-template/internal/types"
+	"iter"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
+// UserServiceClient user_service 客户端，通过 Registry.UserService 获取
+type UserServiceClient struct {
+	*RequestClient
+}
+
 // CreateUserRequest 创建用户请求体（与 user_service API 一致），复用 types.UserBase 并增加 Password
 type CreateUserRequest struct {
 	types.UserBase
@@ -32,52 +41,211 @@ type UpdateUserResponse struct {
 	User types.User `json:"user"`
 }
 
-func (r *RequestClient) GetUserInfo(token string) (*GetUserInfoResponse, error) {
-	baseURL := r.userBaseURL + "/info"
-	raw, err := r.Request(http.MethodGet, baseURL, nil, map[string]string{"Authorization": token})
+// LoginRequest 登录请求体（与 user_service API 一致）
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginResponse 登录响应（与 user_service API 一致）
+type LoginResponse struct {
+	Token string `json:"token"`
+}
+
+// GetUserResponse 查询单个用户响应（与 user_service API 一致）
+type GetUserResponse struct {
+	User types.User `json:"user"`
+}
+
+// ListUsersRequest 用户列表查询条件，作为 query 参数发送，零值条件不发送
+type ListUsersRequest struct {
+	Page     int    // 页码，从 1 开始，<= 0 时为 1
+	PageSize int    // 每页数量，<= 0 时使用 user_service 默认值
+	Keyword  string // 关键字，匹配姓名、登录名
+	Status   int16  // 用户状态：1-在职，2-离职
+	OrgID    *int   // 组织ID，匹配任一级组织
+	RoleCode string // 角色编码
+}
+
+// ListUsersResponse 用户列表响应（与 user_service API 一致）
+type ListUsersResponse struct {
+	Total int64        `json:"total"`
+	List  []types.User `json:"list"`
+}
+
+// BatchGetUsersRequest 批量查询用户请求体（与 user_service API 一致）
+type BatchGetUsersRequest struct {
+	IDs []int `json:"ids"`
+}
+
+// BatchGetUsersResponse 批量查询用户响应（与 user_service API 一致），不存在的 ID 不返回
+type BatchGetUsersResponse struct {
+	List []types.User `json:"list"`
+}
+
+// DeleteUserResponse 删除用户响应（与 user_service API 一致）
+type DeleteUserResponse struct{}
+
+func (r *UserServiceClient) GetUserInfo(ctx context.Context, token string) (*GetUserInfoResponse, error) {
+	baseURL := r.baseURL + "/info"
+	resp, err := doWithToken[GetUserInfoResponse](ctx, r.RequestClient, http.MethodGet, baseURL, nil, token)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := types.ParseBaseResponseFromAny[GetUserInfoResponse](raw)
+	return &resp.Body.Data, nil
+}
+
+// CreateUser 调用 user_service 创建用户接口
+func (r *UserServiceClient) CreateUser(ctx context.Context, token string, req *CreateUserRequest) (*CreateUserResponse, error) {
+	baseURL := r.baseURL + "/"
+	resp, err := doWithToken[CreateUserResponse](ctx, r.RequestClient, http.MethodPost, baseURL, req, token)
 	if err != nil {
 		return nil, err
 	}
-	if !resp.Ok() {
-		return nil, resp.Err()
+	return &resp.Body.Data, nil
+}
+
+// UpdateUser 调用 user_service 更新用户接口
+func (r *UserServiceClient) UpdateUser(ctx context.Context, token string, id int, req *UpdateUserRequest) (*UpdateUserResponse, error) {
+	baseURL := fmt.Sprintf("%s/%d", r.baseURL, id)
+	resp, err := doWithToken[UpdateUserResponse](ctx, r.RequestClient, http.MethodPut, baseURL, req, token)
+	if err != nil {
+		return nil, err
 	}
-	return &resp.Data, nil
+	return &resp.Body.Data, nil
 }
 
-// CreateUser 调用 user_service 创建用户接口
-func (r *RequestClient) CreateUser(token string, req *CreateUserRequest) (*CreateUserResponse, error) {
-	baseURL := r.userBaseURL + "/"
-	raw, err := r.Request(http.MethodPost, baseURL, req, map[string]string{"Authorization": token})
+// Login 调用服务的登录接口（POST {BaseURL}/login，与 user_service API 一致），返回的 token 需加 "Bearer " 前缀后作为 Authorization 使用
+// 系统账号 token 通过该接口获取
+func (r *RequestClient) Login(ctx context.Context, username, password string) (*LoginResponse, error) {
+	baseURL := r.baseURL + "/login"
+	resp, err := Do[LoginResponse](ctx, r, http.MethodPost, baseURL, &LoginRequest{Username: username, Password: password}, nil)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := types.ParseBaseResponseFromAny[CreateUserResponse](raw)
+	return &resp.Body.Data, nil
+}
+
+// GetUserByLoginName 调用 user_service 按登录名查询用户接口，用户不存在时返回 code 为 404 的 *types.ResponseError
+func (r *UserServiceClient) GetUserByLoginName(ctx context.Context, token string, loginName string) (*GetUserResponse, error) {
+	baseURL := r.baseURL + "/login-name/" + url.PathEscape(loginName)
+	ctx = WithRoute(ctx, "/login-name/{login_name}")
+	resp, err := doWithToken[GetUserResponse](ctx, r.RequestClient, http.MethodGet, baseURL, nil, token)
 	if err != nil {
 		return nil, err
 	}
-	if !resp.Ok() {
-		return nil, resp.Err()
+	return &resp.Body.Data, nil
+}
+
+// GetUser 调用 user_service 按 ID 查询用户接口，用户不存在时返回 code 为 404 的 *types.ResponseError
+func (r *UserServiceClient) GetUser(ctx context.Context, token string, id int) (*GetUserResponse, error) {
+	baseURL := fmt.Sprintf("%s/%d", r.baseURL, id)
+	resp, err := doWithToken[GetUserResponse](ctx, r.RequestClient, http.MethodGet, baseURL, nil, token)
+	if err != nil {
+		return nil, err
 	}
-	return &resp.Data, nil
+	return &resp.Body.Data, nil
 }
 
-// UpdateUser 调用 user_service 更新用户接口
-func (r *RequestClient) UpdateUser(token string, id int, req *UpdateUserRequest) (*UpdateUserResponse, error) {
-	baseURL := fmt.Sprintf("%s/%d", r.userBaseURL, id)
-	raw, err := r.Request(http.MethodPut, baseURL, req, map[string]string{"Authorization": token})
+// GetUserBySAPEmployeeID 调用 user_service 按 SAP 员工ID查询用户接口，用户不存在时返回 code 为 404 的 *types.ResponseError
+func (r *UserServiceClient) GetUserBySAPEmployeeID(ctx context.Context, token string, sapEmployeeID int) (*GetUserResponse, error) {
+	baseURL := fmt.Sprintf("%s/sap-employee-id/%d", r.baseURL, sapEmployeeID)
+	resp, err := doWithToken[GetUserResponse](ctx, r.RequestClient, http.MethodGet, baseURL, nil, token)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := types.ParseBaseResponseFromAny[UpdateUserResponse](raw)
+	return &resp.Body.Data, nil
+}
+
+// ListUsers 调用 user_service 分页查询用户接口，支持按关键字、状态、组织和角色过滤
+func (r *UserServiceClient) ListUsers(ctx context.Context, token string, req *ListUsersRequest) (*ListUsersResponse, error) {
+	baseURL := r.baseURL + "/?" + req.query().Encode()
+	resp, err := doWithToken[ListUsersResponse](ctx, r.RequestClient, http.MethodGet, baseURL, nil, token)
 	if err != nil {
 		return nil, err
 	}
-	if !resp.Ok() {
-		return nil, resp.Err()
+	return &resp.Body.Data, nil
+}
+
+// BatchGetUsers 调用 user_service 按 ID 批量查询用户接口
+func (r *UserServiceClient) BatchGetUsers(ctx context.Context, token string, ids []int) (*BatchGetUsersResponse, error) {
+	if len(ids) == 0 {
+		return &BatchGetUsersResponse{}, nil
+	}
+	baseURL := r.baseURL + "/batch"
+	resp, err := doWithToken[BatchGetUsersResponse](ctx, r.RequestClient, http.MethodPost, baseURL, &BatchGetUsersRequest{IDs: ids}, token)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Body.Data, nil
+}
+
+// DeleteUser 调用 user_service 删除用户接口
+func (r *UserServiceClient) DeleteUser(ctx context.Context, token string, id int) error {
+	baseURL := fmt.Sprintf("%s/%d", r.baseURL, id)
+	_, err := doWithToken[DeleteUserResponse](ctx, r.RequestClient, http.MethodDelete, baseURL, nil, token)
+	return err
+}
+
+// AllUsers 按条件逐页查询并逐个返回所有用户，调用方无需处理页码；req.Page 被忽略，PageSize <= 0 时每页 100
+// 返回空页时结束；返回不满一页时，只有已获取数量也达到 Total 才结束（user_service 可能限制 page_size 上限，
+// 不满一页不代表没有更多数据）；未返回 Total 时按不满一页结束
+// 查询出错时返回一次错误后结束，调用方提前 break 时不再请求后续页：
+//
+//	for user, err := range client.AllUsers(ctx, request.SystemAuth, &request.ListUsersRequest{Status: 1}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (r *UserServiceClient) AllUsers(ctx context.Context, token string, req *ListUsersRequest) iter.Seq2[*types.User, error] {
+	return func(yield func(*types.User, error) bool) {
+		page := *req
+		if page.PageSize <= 0 {
+			page.PageSize = 100
+		}
+		var fetched int64
+		for page.Page = 1; ; page.Page++ {
+			resp, err := r.ListUsers(ctx, token, &page)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for i := range resp.List {
+				if !yield(&resp.List[i], nil) {
+					return
+				}
+			}
+			fetched += int64(len(resp.List))
+			if len(resp.List) == 0 || (len(resp.List) < page.PageSize && fetched >= resp.Total) {
+				return
+			}
+		}
+	}
+}
+
+// query 转为 user_service 列表接口的 query 参数
+func (req *ListUsersRequest) query() url.Values {
+	q := url.Values{}
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	q.Set("page", strconv.Itoa(page))
+	if req.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	if req.Keyword != "" {
+		q.Set("keyword", req.Keyword)
+	}
+	if req.Status != 0 {
+		q.Set("status", strconv.Itoa(int(req.Status)))
+	}
+	if req.OrgID != nil {
+		q.Set("org_id", strconv.Itoa(*req.OrgID))
+	}
+	if req.RoleCode != "" {
+		q.Set("role_code", req.RoleCode)
 	}
-	return &resp.Data, nil
+	return q
 }
@@ -0,0 +1,91 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/types"
+)
+
+// newListServer 模拟列表接口，共 count 个用户，返回的 total 固定为 total；maxPageSize > 0 时每页最多返回 maxPageSize 个
+func newListServer(t *testing.T, count int, total int64, maxPageSize int) (*UserServiceClient, *int) {
+	t.Helper()
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		if maxPageSize > 0 {
+			pageSize = min(pageSize, maxPageSize)
+		}
+		resp := ListUsersResponse{Total: total, List: []types.User{}}
+		for id := (page-1)*pageSize + 1; id <= min(page*pageSize, count); id++ {
+			resp.List = append(resp.List, types.User{ID: id})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(types.BaseResponse[ListUsersResponse]{Data: resp})
+	}))
+	t.Cleanup(ts.Close)
+
+	host, port, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	client, err := NewRequestClient("user_service", config.BaseServiceConfig{
+		Scheme:   "http",
+		Host:     host,
+		Port:     portNum,
+		Timeout:  5 * time.Second,
+		AuthMode: config.AuthModeNone,
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewRequestClient: %v", err)
+	}
+	return &UserServiceClient{RequestClient: client}, &requests
+}
+
+func TestAllUsers(t *testing.T) {
+	tests := []struct {
+		name         string
+		count        int
+		total        int64
+		maxPageSize  int
+		wantRequests int
+	}{
+		{"total 准确", 25, 25, 0, 3},
+		{"整页结束时直到空页", 20, 20, 0, 3},
+		{"未返回 total 时直到不满一页", 25, 0, 0, 3},
+		{"未返回 total 且整页结束时直到空页", 20, 0, 0, 3},
+		{"total 偏小时不提前结束", 25, 5, 0, 3},
+		{"page_size 被服务端限制时按 total 继续", 25, 25, 5, 5},
+		{"没有用户", 0, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newListServer(t, tt.count, tt.total, tt.maxPageSize)
+			var got []int
+			for user, err := range client.AllUsers(context.Background(), "", &ListUsersRequest{PageSize: 10}) {
+				if err != nil {
+					t.Fatalf("AllUsers: %v", err)
+				}
+				got = append(got, user.ID)
+			}
+			if len(got) != tt.count {
+				t.Fatalf("返回 %d 个用户, want %d", len(got), tt.count)
+			}
+			for i, id := range got {
+				if id != i+1 {
+					t.Fatalf("第 %d 个用户 ID = %d, want %d", i+1, id, i+1)
+				}
+			}
+			if *requests != tt.wantRequests {
+				t.Fatalf("请求 %d 次, want %d", *requests, tt.wantRequests)
+			}
+		})
+	}
+}
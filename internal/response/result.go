@@ -13,8 +13,10 @@ type Result struct {
 	Data any    `json:"data"`
 }
 
+// Response 写入 Result 响应体，HTTP 状态码按 SetStatusMode 设置的模式决定（默认始终为 200）
 func Response(w http.ResponseWriter, resp any, err error) {
 	var res Result
+	status := http.StatusOK
 	if err != nil {
 		var e *Error
 		switch {
@@ -25,10 +27,11 @@ func Response(w http.ResponseWriter, resp any, err error) {
 			res.Code = http.StatusInternalServerError
 			res.Msg = err.Error()
 		}
+		status = httpStatus(res.Code)
 	} else {
 		res.Code = http.StatusOK
 		res.Msg = SUCCESS
 		res.Data = resp
 	}
-	httpx.OkJson(w, res)
+	httpx.WriteJson(w, status, res)
 }
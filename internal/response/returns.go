@@ -1,7 +1,15 @@
 package response
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+)
 
 // 服务器错误
 var InternalServerError = NewError(http.StatusInternalServerError, "服务器错误")
 var ParseError = NewError(10005, "解析请求失败")
+
+// NewParseError 包装 httpx.Parse 返回的错误，code 与 ParseError 一致，msg 附带具体原因
+func NewParseError(err error) *Error {
+	return NewError(ParseError.Code, fmt.Sprintf("%s: %s", ParseError.Msg, err.Error()))
+}
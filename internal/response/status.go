@@ -0,0 +1,47 @@
+package response
+
+import "net/http"
+
+// 响应 HTTP 状态码模式，响应体 Result 在两种模式下一致
+const (
+	// StatusModeAlwaysOK 始终返回 200，错误只体现在 Result.Code（默认，兼容现有客户端）
+	StatusModeAlwaysOK = "always_ok"
+	// StatusModeHTTP 按 Error.Code 返回真实的 HTTP 状态码，便于网关、负载均衡和告警识别失败请求
+	StatusModeHTTP = "http"
+)
+
+var (
+	statusMode    = StatusModeAlwaysOK
+	defaultStatus = http.StatusInternalServerError
+	// codeStatus 自定义 code 对应的 HTTP 状态码，优先于 code 本身
+	codeStatus = map[int]int{
+		ParseError.Code: http.StatusBadRequest,
+	}
+)
+
+// SetStatusMode 设置响应 HTTP 状态码模式，仅在启动时（处理请求前）调用
+// fallback 为 http 模式下未映射且不是合法 HTTP 状态码的 code 对应的状态码，mapping 覆盖或追加自定义 code 的映射
+func SetStatusMode(mode string, fallback int, mapping map[int]int) {
+	statusMode = mode
+	if fallback > 0 {
+		defaultStatus = fallback
+	}
+	for code, status := range mapping {
+		codeStatus[code] = status
+	}
+}
+
+// httpStatus 错误 code 对应的 HTTP 状态码：always_ok 模式为 200；http 模式依次取映射、4xx/5xx 状态码、fallback
+// code 为 200 等非错误状态码时也返回 fallback，避免错误响应被网关和客户端当作成功
+func httpStatus(code int) int {
+	if statusMode != StatusModeHTTP {
+		return http.StatusOK
+	}
+	if status, ok := codeStatus[code]; ok {
+		return status
+	}
+	if code >= 400 && code < 600 && http.StatusText(code) != "" {
+		return code
+	}
+	return defaultStatus
+}
@@ -0,0 +1,205 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go-zero-template/internal/lock"
+	"go-zero-template/internal/models"
+
+	"github.com/robfig/cron/v3"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// misfireScanLimit 单次检查最多遍历的计划触发时间数，防止高频表达式在长时间停机后遍历过久
+const misfireScanLimit = 100000
+
+// checkMisfires 检查已启用任务错过的调度（停机、leader 切换期间），按任务的补偿策略处理，仅 leader 执行
+// 以数据库中最近一次计划运行的计划触发时间为起点，任务定义更新后从更新时间起算（暂停期间的调度不补偿）
+func (s *Scheduler) checkMisfires(ctx context.Context) {
+	if s.elector != nil && !s.elector.IsLeader() {
+		return
+	}
+
+	tasks, err := s.repo.CronTask.ListEnabled(ctx)
+	if err != nil {
+		s.writer.Error("检查错过的调度失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "Scheduler.checkMisfires"),
+			writer.Field("username", "system"),
+			writer.Field("error", err.Error()),
+		)
+		return
+	}
+
+	now := time.Now()
+	for i := range tasks {
+		task := tasks[i]
+		missed, err := s.missedWindows(ctx, task, now)
+		if err != nil {
+			s.writer.Error("查询最近一次计划运行失败",
+				writer.Field("log_type", "database"),
+				writer.Field("trace", "Scheduler."+task.Name),
+				writer.Field("username", "system"),
+				writer.Field("task_name", task.Name),
+				writer.Field("error", err.Error()),
+			)
+			continue
+		}
+		if len(missed) > 0 {
+			s.catchUp(task, missed)
+		}
+	}
+}
+
+// missedWindows 计算任务在 (起点, now-MisfireThreshold] 内错过的计划触发时间，按时间正序
+func (s *Scheduler) missedWindows(ctx context.Context, task models.CronTask, now time.Time) ([]time.Time, error) {
+	schedule, err := cron.ParseStandard(task.CronExpr)
+	if err != nil {
+		// 表达式非法的任务在 Sync 时已记录日志
+		return nil, nil
+	}
+
+	since := task.UpdatedAt
+	last, err := s.repo.CronJobRun.LastScheduledAt(ctx, task.ID, []string{TriggerSchedule, TriggerCatchup})
+	if err != nil {
+		return nil, err
+	}
+	if last != nil && last.After(since) {
+		since = *last
+	}
+	s.mu.RLock()
+	caughtUp, ok := s.caughtUp[task.ID]
+	s.mu.RUnlock()
+	if ok && caughtUp.After(since) {
+		since = caughtUp
+	}
+
+	return scanWindows(schedule, since.In(s.location), now.Add(-s.config.MisfireThreshold), misfireScanLimit), nil
+}
+
+// scanWindows 返回 (since, deadline] 内的计划触发时间，按时间正序，超过 limit 个时只保留最近的 limit 个
+// 超出上限后按已遍历窗口的平均间隔直接跳到 deadline 前约 2*limit 个窗口处（留出余量应对不规则的表达式），
+// 中间更早的窗口最终也会被丢弃，无需逐个遍历
+func scanWindows(schedule cron.Schedule, since, deadline time.Time, limit int) []time.Time {
+	var windows []time.Time
+	t := schedule.Next(since)
+	for ; !t.IsZero() && !t.After(deadline) && len(windows) < limit; t = schedule.Next(t) {
+		windows = append(windows, t)
+	}
+	if t.IsZero() || t.After(deadline) {
+		return windows
+	}
+
+	if limit > 1 {
+		interval := windows[limit-1].Sub(windows[0]) / time.Duration(limit-1)
+		if skip := deadline.Add(-2 * interval * time.Duration(limit)); skip.After(t) {
+			windows = windows[:0]
+			t = schedule.Next(skip)
+		}
+	}
+	// 环形缓冲，head 为最早窗口的下标
+	head := 0
+	for ; !t.IsZero() && !t.After(deadline); t = schedule.Next(t) {
+		if len(windows) < limit {
+			windows = append(windows, t)
+			continue
+		}
+		windows[head] = t
+		head = (head + 1) % limit
+	}
+	return slices.Concat(windows[head:], windows[:head])
+}
+
+// catchUp 按任务的补偿策略处理错过的调度，补偿运行在后台按时间顺序逐个执行
+func (s *Scheduler) catchUp(task models.CronTask, missed []time.Time) {
+	var fire []time.Time
+	switch task.MisfirePolicy {
+	case models.MisfireFireOnce:
+		fire = missed[len(missed)-1:]
+	case models.MisfireFireAll:
+		limit := task.MaxCatchup
+		if limit <= 0 {
+			limit = s.config.MaxCatchup
+		}
+		fire = missed
+		if len(fire) > limit {
+			// 超出上限时只补执行最近的 limit 次
+			fire = fire[len(fire)-limit:]
+		}
+	}
+
+	if !s.HasHandler(task.Handler) {
+		// 不推进 caughtUp，处理器注册后的检查会重新补偿
+		s.writer.Error("处理器未注册，暂不补偿错过的调度",
+			writer.Field("log_type", "system"),
+			writer.Field("trace", "Scheduler."+task.Name),
+			writer.Field("username", "system"),
+			writer.Field("task_name", task.Name),
+			writer.Field("handler", task.Handler),
+			writer.Field("missed", len(missed)),
+		)
+		return
+	}
+
+	// 先推进到最后一个错过的窗口，避免补偿进行中被重复检测；提前退出时回退到最后一个已执行的窗口
+	s.mu.Lock()
+	prev, hadPrev := s.caughtUp[task.ID]
+	s.caughtUp[task.ID] = missed[len(missed)-1]
+	s.mu.Unlock()
+	rollback := func(dispatched int) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case dispatched > 0:
+			s.caughtUp[task.ID] = fire[dispatched-1]
+		case hadPrev:
+			s.caughtUp[task.ID] = prev
+		default:
+			delete(s.caughtUp, task.ID)
+		}
+	}
+
+	s.writer.Info("检测到错过的调度",
+		writer.Field("log_type", "system"),
+		writer.Field("trace", "Scheduler."+task.Name),
+		writer.Field("username", "system"),
+		writer.Field("task_name", task.Name),
+		writer.Field("misfire_policy", task.MisfirePolicy),
+		writer.Field("missed", len(missed)),
+		writer.Field("catchup", len(fire)),
+		writer.Field("first_missed", missed[0].Format(time.RFC3339)),
+		writer.Field("last_missed", missed[len(missed)-1].Format(time.RFC3339)),
+	)
+	if len(fire) == 0 {
+		return
+	}
+
+	s.manual.Add(1)
+	go func() {
+		defer s.manual.Done()
+		for i := range fire {
+			scheduledAt := fire[i]
+			select {
+			case <-s.stop:
+				rollback(i)
+				return
+			default:
+			}
+
+			ctx := s.ctx
+			var token int64
+			if s.elector != nil {
+				var ok bool
+				if token, ok = s.elector.Token(); !ok {
+					// 失去 leader 身份，剩余补偿由新 leader 重新计算
+					rollback(i)
+					return
+				}
+				ctx = lock.WithToken(ctx, token)
+			}
+			s.runWithPolicy(ctx, task, TriggerCatchup, token, &scheduledAt)
+		}
+	}()
+}
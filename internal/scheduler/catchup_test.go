@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+func TestScanWindows(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("加载时区失败: %v", err)
+	}
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name     string
+		spec     string
+		deadline time.Time
+		limit    int
+	}{
+		{"未超出上限", "*/10 * * * *", since.Add(time.Hour), 100},
+		{"恰好等于上限", "*/10 * * * *", since.Add(time.Hour), 6},
+		{"超出上限保留最近的窗口", "* * * * *", since.AddDate(0, 0, 30), 5},
+		{"不规则表达式超出上限", "30 9 * * 1-5", since.AddDate(2, 0, 0), 7},
+		{"月末表达式超出上限", "0 0 1,15 * *", since.AddDate(5, 0, 0), 3},
+		{"上限为 1 只保留最后一个", "0 * * * *", since.AddDate(0, 1, 0), 1},
+		{"没有错过的窗口", "0 0 1 1 *", since.Add(time.Hour), 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := cron.ParseStandard(tt.spec)
+			if err != nil {
+				t.Fatalf("解析表达式失败: %v", err)
+			}
+
+			var all []time.Time
+			for w := schedule.Next(since); !w.After(tt.deadline); w = schedule.Next(w) {
+				all = append(all, w)
+			}
+			want := all[max(len(all)-tt.limit, 0):]
+
+			got := scanWindows(schedule, since, tt.deadline, tt.limit)
+			if !slices.EqualFunc(got, want, time.Time.Equal) {
+				t.Fatalf("共 %d 个窗口，scanWindows 返回 %d 个（%v ~ %v），want 最近的 %d 个（%v ~ %v）",
+					len(all), len(got), first(got), last(got), len(want), first(want), last(want))
+			}
+		})
+	}
+}
+
+func first(windows []time.Time) time.Time {
+	if len(windows) == 0 {
+		return time.Time{}
+	}
+	return windows[0]
+}
+
+func last(windows []time.Time) time.Time {
+	if len(windows) == 0 {
+		return time.Time{}
+	}
+	return windows[len(windows)-1]
+}
@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go-zero-template/internal/lock"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/utils"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+const (
+	// slotKeyPrefix 任务运行槽的 Redis key 前缀，forbid/replace 任务同一时刻只有一个运行持有运行槽
+	slotKeyPrefix = "cron:task:running:"
+	// cancelChannel 取消运行通知的 Redis 频道，消息内容为运行槽持有者标识
+	cancelChannel = "cron:scheduler:cancel"
+	// slotPollInterval replace 等待旧运行释放运行槽的轮询间隔
+	slotPollInterval = 200 * time.Millisecond
+)
+
+var (
+	// ErrOverlap 上一次运行尚未结束（forbid）
+	ErrOverlap = errors.New("上一次运行尚未结束")
+	// errReplaced 运行被新的运行替换（replace）
+	errReplaced = errors.New("运行被新的运行替换")
+	// errReplaceTimeout 等待被替换的运行结束超时
+	errReplaceTimeout = errors.New("等待上一次运行结束超时")
+)
+
+// slotSeq 运行槽持有者序号，与实例标识组成每次运行唯一的持有者，避免同实例重入
+var slotSeq atomic.Int64
+
+// runWithPolicy 按任务的并发策略执行一次计划运行（含补偿）
+// forbid/replace 通过 Redis 运行槽跨实例互斥，运行期间自动续期，运行槽丢失时取消运行
+func (s *Scheduler) runWithPolicy(ctx context.Context, task models.CronTask, trigger string, token int64, scheduledAt *time.Time) {
+	if token > 0 && !s.fence(ctx, task, token) {
+		return
+	}
+	if !exclusive(task) {
+		record := s.startRun(task, trigger, token, 0, scheduledAt)
+		s.complete(ctx, task, record)
+		return
+	}
+
+	locker := s.newSlotLocker()
+	slot, err := s.acquireSlot(ctx, locker, task)
+	if err != nil {
+		s.skipRun(task, trigger, token, scheduledAt, err)
+		return
+	}
+	record := s.startRun(task, trigger, token, 0, scheduledAt)
+	s.runInSlot(ctx, task, locker, slot, record)
+}
+
+// fence 执行前以 fencing token 标记任务，已有更新的 leader 运行过该任务（本实例的 leader 身份已过期）
+// 或无法校验时返回 false，跳过本次运行
+func (s *Scheduler) fence(ctx context.Context, task models.CronTask, token int64) bool {
+	ok, err := s.repo.CronTask.Fence(ctx, task.ID, token)
+	if err != nil {
+		s.writer.Error("校验 fencing token 失败，跳过本次运行",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "CronTask.Fence"),
+			writer.Field("username", "system"),
+			writer.Field("task_name", task.Name),
+			writer.Field("fencing_token", token),
+			writer.Field("error", err.Error()),
+		)
+		return false
+	}
+	if !ok {
+		s.writer.Error("fencing token 已过期，跳过本次运行",
+			writer.Field("log_type", "system"),
+			writer.Field("trace", "Scheduler."+task.Name),
+			writer.Field("username", "system"),
+			writer.Field("task_name", task.Name),
+			writer.Field("fencing_token", token),
+			writer.Field("error", lock.ErrLeaseLost.Error()),
+		)
+	}
+	return ok
+}
+
+// exclusive 任务是否需要持有运行槽（forbid/replace）
+func exclusive(task models.CronTask) bool {
+	return task.ConcurrencyPolicy == models.ConcurrencyForbid || task.ConcurrencyPolicy == models.ConcurrencyReplace
+}
+
+// newSlotLocker 为一次运行创建运行槽持有者
+func (s *Scheduler) newSlotLocker() *lock.Locker {
+	return lock.NewLocker(s.redis, utils.InstanceID()+":"+strconv.FormatInt(slotSeq.Add(1), 10))
+}
+
+// runInSlot 持有运行槽执行已写入的运行，运行期间自动续期，结束后释放运行槽
+func (s *Scheduler) runInSlot(ctx context.Context, task models.CronTask, locker *lock.Locker, slot *lock.Lease, record *models.CronJobRun) {
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := slot.Release(releaseCtx); err != nil {
+			logx.Errorf("释放任务运行槽失败: key=%s, err=%v", slot.Key(), err)
+		}
+	}()
+
+	runCtx, stopKeepAlive := slot.KeepAlive(ctx)
+	defer stopKeepAlive()
+	runCtx, cancel := context.WithCancelCause(runCtx)
+	defer cancel(nil)
+
+	owner := locker.Owner()
+	s.mu.Lock()
+	s.running[owner] = cancel
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, owner)
+		s.mu.Unlock()
+	}()
+
+	s.complete(runCtx, task, record)
+}
+
+// acquireSlot 获取任务运行槽；replace 策略下通知当前持有者取消运行，并在 LeaseTTL 内等待其释放
+func (s *Scheduler) acquireSlot(ctx context.Context, locker *lock.Locker, task models.CronTask) (*lock.Lease, error) {
+	key := slotKeyPrefix + strconv.FormatInt(task.ID, 10)
+	slot, err := locker.Acquire(ctx, key, s.config.LeaseTTL)
+	if err == nil {
+		return slot, nil
+	}
+	if !errors.Is(err, lock.ErrNotAcquired) {
+		s.writer.Error("获取任务运行槽失败",
+			writer.Field("log_type", "redis"),
+			writer.Field("trace", "Scheduler."+task.Name),
+			writer.Field("username", "system"),
+			writer.Field("task_name", task.Name),
+			writer.Field("error", err.Error()),
+		)
+		return nil, fmt.Errorf("获取任务运行槽失败: %w", err)
+	}
+	if task.ConcurrencyPolicy != models.ConcurrencyReplace {
+		return nil, ErrOverlap
+	}
+
+	owner, err := s.redis.Get(ctx, key).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("查询任务运行槽失败: %w", err)
+	}
+	if owner != "" {
+		if err := s.redis.Publish(ctx, cancelChannel, owner).Err(); err != nil {
+			return nil, fmt.Errorf("发布取消运行通知失败: %w", err)
+		}
+	}
+
+	// 持有者宕机时运行槽最长 LeaseTTL 后过期
+	deadline := time.Now().Add(s.config.LeaseTTL)
+	ticker := time.NewTicker(slotPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+		slot, err = locker.Acquire(ctx, key, s.config.LeaseTTL)
+		if !errors.Is(err, lock.ErrNotAcquired) {
+			return slot, err
+		}
+		if time.Now().After(deadline) {
+			return nil, errReplaceTimeout
+		}
+	}
+}
+
+// cancelRun 取消本实例上持有指定运行槽的运行，不在本实例时忽略
+func (s *Scheduler) cancelRun(owner string) {
+	s.mu.RLock()
+	cancel, ok := s.running[owner]
+	s.mu.RUnlock()
+	if ok {
+		cancel(errReplaced)
+	}
+}
+
+// skipRun 记录一次被跳过的运行
+func (s *Scheduler) skipRun(task models.CronTask, trigger string, token int64, scheduledAt *time.Time, reason error) {
+	record := newRun(task, trigger, token, 0, scheduledAt)
+	record.Status = models.RunStatusSkipped
+	record.FinishedAt = &record.StartedAt
+	record.Error = reason.Error()
+	s.createRun(record)
+	s.logSkip(task, record, reason)
+}
+
+// skipStartedRun 已写入的运行未能获取运行槽（手动执行 replace 任务时等待上一次运行结束超时），更新为跳过
+func (s *Scheduler) skipStartedRun(task models.CronTask, record *models.CronJobRun, reason error) {
+	finishedAt := time.Now()
+	record.Status = models.RunStatusSkipped
+	record.FinishedAt = &finishedAt
+	record.Error = reason.Error()
+	s.updateRun(record)
+	s.logSkip(task, record, reason)
+}
+
+// logSkip 记录跳过执行日志
+func (s *Scheduler) logSkip(task models.CronTask, record *models.CronJobRun, reason error) {
+	s.writer.Info("定时任务跳过执行",
+		writer.Field("log_type", "system"),
+		writer.Field("trace", "Scheduler."+task.Name),
+		writer.Field("username", "system"),
+		writer.Field("task_name", task.Name),
+		writer.Field("run_id", record.ID),
+		writer.Field("trigger", record.Trigger),
+		writer.Field("concurrency_policy", task.ConcurrencyPolicy),
+		writer.Field("reason", reason.Error()),
+	)
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"go-zero-template/internal/models"
+)
+
+// permanentError 不可重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent 将错误标记为不可重试，处理器遇到重试也无法恢复的错误（如参数错误）时使用
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 错误是否被标记为不可重试
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// RetryPolicy 任务失败重试策略
+type RetryPolicy struct {
+	MaxAttempts    int              // 总尝试次数（含首次），<= 1 表示不重试
+	BackoffBase    time.Duration    // 首次重试前的等待时间，之后每次翻倍
+	BackoffMax     time.Duration    // 单次等待时间上限
+	Jitter         float64          // 随机抖动比例（0~1），等待时间在 [d*(1-Jitter), d] 之间
+	RetryOnTimeout bool             // 超时是否重试
+	Retryable      func(error) bool // 处理器自定义的可重试判断，nil 表示除 Permanent 外都可重试
+}
+
+// policyFor 根据任务定义与处理器注册信息生成重试策略
+func policyFor(task models.CronTask, route Route) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    task.MaxAttempts,
+		BackoffBase:    time.Duration(task.RetryBackoff) * time.Second,
+		BackoffMax:     time.Duration(task.RetryBackoffMax) * time.Second,
+		Jitter:         task.RetryJitter,
+		RetryOnTimeout: task.RetryOnTimeout == nil || *task.RetryOnTimeout,
+		Retryable:      route.Retryable,
+	}
+}
+
+// ShouldRetry 第 attempt 次尝试失败后是否继续重试
+func (p RetryPolicy) ShouldRetry(attempt int, err error) bool {
+	return attempt < p.MaxAttempts && p.IsRetryable(err)
+}
+
+// IsRetryable 错误是否可重试
+func (p RetryPolicy) IsRetryable(err error) bool {
+	switch {
+	case err == nil, IsPermanent(err):
+		return false
+	case errors.Is(err, context.DeadlineExceeded):
+		return p.RetryOnTimeout
+	case p.Retryable != nil:
+		return p.Retryable(err)
+	default:
+		return true
+	}
+}
+
+// Backoff 第 attempt 次尝试失败后的等待时间：BackoffBase * 2^(attempt-1)，不超过 BackoffMax，并叠加抖动
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	d := p.BackoffBase
+	for i := 1; i < attempt && (p.BackoffMax <= 0 || d < p.BackoffMax); i++ {
+		d *= 2
+	}
+	if p.BackoffMax > 0 && d > p.BackoffMax {
+		d = p.BackoffMax
+	}
+	if p.Jitter > 0 && d > 0 {
+		jitter := min(p.Jitter, 1)
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+	return d
+}
@@ -0,0 +1,306 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-zero-template/internal/lock"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/utils"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// 运行触发方式
+const (
+	TriggerSchedule = "schedule" // 按 cron 表达式调度
+	TriggerManual   = "manual"   // 手动立即执行
+	TriggerRedrive  = "redrive"  // 死信重新投递
+	TriggerCatchup  = "catchup"  // 补偿停机期间错过的调度
+)
+
+// run 按调度执行一次任务，非 leader 实例跳过，保证每次调度只在一个实例上执行
+func (s *Scheduler) run(task models.CronTask) {
+	// cron 在计划时间（整秒）触发，截断到秒即为本次计划触发时间
+	scheduledAt := time.Now().Truncate(time.Second)
+	ctx := s.ctx
+	var token int64
+	if s.elector != nil {
+		var ok bool
+		if token, ok = s.elector.Token(); !ok {
+			return
+		}
+		ctx = lock.WithToken(ctx, token)
+	}
+
+	s.runWithPolicy(ctx, task, TriggerSchedule, token, &scheduledAt)
+}
+
+// RunNow 在本实例上立即异步执行一次任务（不受 leader 限制，受并发策略限制），返回运行记录 ID
+func (s *Scheduler) RunNow(task models.CronTask) (int64, error) {
+	return s.dispatch(task, TriggerManual, 0)
+}
+
+// Redrive 重新投递死信运行：在本实例上异步重新执行对应任务，返回新的运行记录 ID
+func (s *Scheduler) Redrive(task models.CronTask, deadRun *models.CronJobRun) (int64, error) {
+	if deadRun.Status != models.RunStatusDeadLetter {
+		return 0, fmt.Errorf("运行记录不在死信状态: %s", deadRun.Status)
+	}
+	return s.dispatch(task, TriggerRedrive, deadRun.ID)
+}
+
+// dispatch 写入运行记录后异步执行，Stop 时会等待执行结束
+// forbid/replace 任务与计划运行使用同一个运行槽：forbid 在写入运行记录前获取，上一次运行未结束时返回 ErrOverlap；
+// replace 在后台取消上一次运行并等待其释放运行槽，等待超时时运行记录为 skipped
+func (s *Scheduler) dispatch(task models.CronTask, trigger string, redriveOf int64) (int64, error) {
+	if !s.HasHandler(task.Handler) {
+		return 0, fmt.Errorf("处理器未注册: %s", task.Handler)
+	}
+
+	// 手动执行和重新投递不要求 leader 身份，不携带 fencing token
+	ctx := s.ctx
+	var (
+		locker *lock.Locker
+		slot   *lock.Lease
+	)
+	if exclusive(task) {
+		locker = s.newSlotLocker()
+		if task.ConcurrencyPolicy == models.ConcurrencyForbid {
+			var err error
+			if slot, err = s.acquireSlot(ctx, locker, task); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	record := s.startRun(task, trigger, 0, redriveOf, nil)
+	s.manual.Add(1)
+	go func() {
+		defer s.manual.Done()
+		if locker == nil {
+			s.complete(ctx, task, record)
+			return
+		}
+		if slot == nil {
+			var err error
+			if slot, err = s.acquireSlot(ctx, locker, task); err != nil {
+				s.skipStartedRun(task, record, err)
+				return
+			}
+		}
+		s.runInSlot(ctx, task, locker, slot, record)
+	}()
+	return record.ID, nil
+}
+
+// complete 按重试策略执行任务、更新运行记录并写日志
+func (s *Scheduler) complete(ctx context.Context, task models.CronTask, record *models.CronJobRun) {
+	trace := "Scheduler." + task.Name
+	route, _ := s.handler(task.Handler)
+	policy := policyFor(task, route)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		record.Attempts = attempt
+		err = s.execute(ctx, task)
+		if err == nil || ctx.Err() != nil || !policy.ShouldRetry(attempt, err) {
+			break
+		}
+
+		delay := policy.Backoff(attempt)
+		s.writer.Error("定时任务执行失败，等待重试",
+			writer.Field("log_type", "system"),
+			writer.Field("trace", trace),
+			writer.Field("username", "system"),
+			writer.Field("task_name", task.Name),
+			writer.Field("run_id", record.ID),
+			writer.Field("attempt", attempt),
+			writer.Field("max_attempts", policy.MaxAttempts),
+			writer.Field("retry_after", delay.String()),
+			writer.Field("error", err.Error()),
+		)
+		if !s.sleep(ctx, delay) {
+			break
+		}
+	}
+	if err != nil && errors.Is(context.Cause(ctx), errReplaced) {
+		err = errReplaced
+	}
+
+	s.finishRun(record, err, policy)
+	duration := time.Duration(record.DurationMs) * time.Millisecond
+
+	if err != nil {
+		msg := "定时任务执行失败"
+		if record.Status == models.RunStatusDeadLetter {
+			msg = "定时任务执行失败，进入死信"
+		}
+		s.writer.Error(msg,
+			writer.Field("log_type", "system"),
+			writer.Field("trace", trace),
+			writer.Field("username", "system"),
+			writer.Field("task_name", task.Name),
+			writer.Field("run_id", record.ID),
+			writer.Field("trigger", record.Trigger),
+			writer.Field("status", record.Status),
+			writer.Field("attempts", record.Attempts),
+			writer.Field("duration", duration.String()),
+			writer.Field("error", err.Error()),
+		)
+		return
+	}
+
+	s.writer.Info("定时任务执行完成",
+		writer.Field("log_type", "system"),
+		writer.Field("trace", trace),
+		writer.Field("username", "system"),
+		writer.Field("task_name", task.Name),
+		writer.Field("run_id", record.ID),
+		writer.Field("trigger", record.Trigger),
+		writer.Field("attempts", record.Attempts),
+		writer.Field("duration", duration.String()),
+	)
+}
+
+// sleep 等待重试间隔，调度器停止或运行被取消时提前返回 false
+func (s *Scheduler) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-s.stop:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// execute 调用任务处理器，每次尝试的超时由任务定义的 Timeout 控制
+func (s *Scheduler) execute(ctx context.Context, task models.CronTask) error {
+	route, ok := s.handler(task.Handler)
+	if !ok {
+		return Permanent(fmt.Errorf("处理器未注册: %s", task.Handler))
+	}
+
+	if timeout := task.TimeoutDuration(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := safeCall(ctx, route.Handler)
+	if err == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// 处理器忽略了 ctx 但已超时，仍按超时处理
+		err = ctx.Err()
+	}
+	return err
+}
+
+// startRun 写入运行中记录，写库失败时只记录日志，不影响任务执行
+func (s *Scheduler) startRun(task models.CronTask, trigger string, token int64, redriveOf int64, scheduledAt *time.Time) *models.CronJobRun {
+	record := newRun(task, trigger, token, redriveOf, scheduledAt)
+	s.createRun(record)
+	return record
+}
+
+func newRun(task models.CronTask, trigger string, token int64, redriveOf int64, scheduledAt *time.Time) *models.CronJobRun {
+	return &models.CronJobRun{
+		TaskID:       task.ID,
+		TaskName:     task.Name,
+		Handler:      task.Handler,
+		Trigger:      trigger,
+		Status:       models.RunStatusRunning,
+		ScheduledAt:  scheduledAt,
+		StartedAt:    time.Now(),
+		Instance:     utils.InstanceID(),
+		FencingToken: token,
+		RedriveOf:    redriveOf,
+	}
+}
+
+func (s *Scheduler) createRun(record *models.CronJobRun) {
+	if err := s.repo.CronJobRun.Create(context.Background(), record); err != nil {
+		s.writer.Error("写入任务运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "CronJobRun.Create"),
+			writer.Field("username", "system"),
+			writer.Field("task_name", record.TaskName),
+			writer.Field("error", err.Error()),
+		)
+	}
+}
+
+// finishRun 根据执行结果更新运行记录
+func (s *Scheduler) finishRun(record *models.CronJobRun, err error, policy RetryPolicy) {
+	finishedAt := time.Now()
+	record.FinishedAt = &finishedAt
+	record.DurationMs = finishedAt.Sub(record.StartedAt).Milliseconds()
+	record.Status = runStatus(err, policy)
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	s.updateRun(record)
+}
+
+// updateRun 将运行结果写回运行记录，写入运行记录失败（ID 为 0）时跳过
+// fencing token 已过期时写入被拒绝，视为本次运行期间失去 leader 身份
+func (s *Scheduler) updateRun(record *models.CronJobRun) {
+	if record.ID == 0 {
+		return
+	}
+	ok, err := s.repo.CronJobRun.Finish(context.Background(), record)
+	if err != nil {
+		s.writer.Error("更新任务运行记录失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "CronJobRun.Finish"),
+			writer.Field("username", "system"),
+			writer.Field("task_name", record.TaskName),
+			writer.Field("run_id", record.ID),
+			writer.Field("error", err.Error()),
+		)
+		return
+	}
+	if !ok && record.FencingToken > 0 {
+		s.writer.Error("fencing token 已过期，运行结果未写入",
+			writer.Field("log_type", "system"),
+			writer.Field("trace", "CronJobRun.Finish"),
+			writer.Field("username", "system"),
+			writer.Field("task_name", record.TaskName),
+			writer.Field("run_id", record.ID),
+			writer.Field("fencing_token", record.FencingToken),
+			writer.Field("status", record.Status),
+			writer.Field("error", lock.ErrLeaseLost.Error()),
+		)
+	}
+}
+
+// runStatus 运行结束后的状态：可重试的错误用完全部尝试次数（未开启重试时只有一次），或重试等待、执行被停机和取消打断后进入死信，
+// 可通过重新投递再次执行；不可重试的错误（Permanent、不重试的超时）为 failed 或 timeout，不能重新投递
+func runStatus(err error, policy RetryPolicy) string {
+	switch {
+	case err == nil:
+		return models.RunStatusSuccess
+	case errors.Is(err, errReplaced):
+		return models.RunStatusCanceled
+	case policy.IsRetryable(err):
+		return models.RunStatusDeadLetter
+	case errors.Is(err, context.DeadlineExceeded):
+		return models.RunStatusTimeout
+	default:
+		return models.RunStatusFailed
+	}
+}
+
+// safeCall 执行处理器并将 panic 转为不可重试的 error
+func safeCall(ctx context.Context, handler Handler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = Permanent(fmt.Errorf("panic: %v", p))
+		}
+	}()
+	return handler(ctx)
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/models"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+func TestRunStatus(t *testing.T) {
+	errFailed := errors.New("failed")
+	noRetry := RetryPolicy{}
+	retry := RetryPolicy{MaxAttempts: 3, RetryOnTimeout: true}
+
+	tests := []struct {
+		name   string
+		err    error
+		policy RetryPolicy
+		want   string
+	}{
+		{"成功", nil, retry, models.RunStatusSuccess},
+		{"被新的运行取消", errReplaced, retry, models.RunStatusCanceled},
+		{"未开启重试的可重试错误进入死信", errFailed, noRetry, models.RunStatusDeadLetter},
+		{"重试耗尽或重试等待被打断进入死信", errFailed, retry, models.RunStatusDeadLetter},
+		{"停机时取消执行进入死信", context.Canceled, retry, models.RunStatusDeadLetter},
+		{"不可重试的错误", Permanent(errFailed), noRetry, models.RunStatusFailed},
+		{"超时可重试进入死信", context.DeadlineExceeded, retry, models.RunStatusDeadLetter},
+		{"超时不重试", context.DeadlineExceeded, RetryPolicy{MaxAttempts: 3}, models.RunStatusTimeout},
+		{"处理器判断不可重试", errFailed, RetryPolicy{Retryable: func(error) bool { return false }}, models.RunStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runStatus(tt.err, tt.policy); got != tt.want {
+				t.Errorf("runStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStopDuringBackoff(t *testing.T) {
+	s := NewScheduler(config.SchedulerConfig{ShutdownTimeout: time.Second}, nil, nil, writer.NewMultiWriter(writer.NewConsoleWriter()))
+	called := make(chan struct{}, 1)
+	s.AddHandlers(Route{Name: "fail", Handler: func(context.Context) error {
+		called <- struct{}{}
+		return errors.New("failed")
+	}})
+	task := models.CronTask{Name: "fail", Handler: "fail", MaxAttempts: 5, RetryBackoff: 60}
+	// ID 为 0 的运行记录不写库
+	record := &models.CronJobRun{StartedAt: time.Now()}
+
+	s.manual.Add(1)
+	go func() {
+		defer s.manual.Done()
+		s.complete(s.ctx, task, record)
+	}()
+	<-called
+
+	// 首次失败后进入 60 秒的重试等待，停机打断等待
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Stop 阻塞")
+	}
+
+	if record.Attempts != 1 {
+		t.Fatalf("Attempts = %d, want 1", record.Attempts)
+	}
+	if record.Status != models.RunStatusDeadLetter {
+		t.Fatalf("Status = %q, want %q", record.Status, models.RunStatusDeadLetter)
+	}
+}
@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/db"
+	"go-zero-template/internal/lock"
+	"go-zero-template/internal/utils"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/robfig/cron/v3"
+	"github.com/zeromicro/go-zero/core/logx"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// Handler 定时任务处理函数
+type Handler func(ctx context.Context) error
+
+// Route 按名称注册的任务处理器，Name 对应 cron_tasks.handler
+type Route struct {
+	Name    string
+	Handler Handler
+	// Retryable 可选，判断处理器返回的错误是否可重试，nil 表示除 Permanent 外都可重试
+	Retryable func(error) bool
+}
+
+const (
+	// leaderKey 调度器 leader 租约的 Redis key
+	leaderKey = "cron:scheduler:leader"
+	// reloadChannel 任务定义变更通知的 Redis 频道
+	reloadChannel = "cron:scheduler:reload"
+)
+
+// Scheduler 定时任务调度器，从数据库加载任务定义并按 cron 表达式执行
+// 多副本部署时通过 Redis 租约选出唯一 leader，只有 leader 实际执行任务
+type Scheduler struct {
+	config   config.SchedulerConfig
+	repo     *db.Repository
+	redis    *redis.Client
+	writer   *writer.MultiWriter
+	cron     *cron.Cron
+	location *time.Location
+	elector  *lock.Elector
+
+	mu       sync.RWMutex
+	handlers map[string]Route
+	entries  map[int64]entry
+	// caughtUp 每个任务已处理过的最近一次错过的计划触发时间，避免补偿进行中被重复检测
+	caughtUp map[int64]time.Time
+	// running 本实例上持有运行槽的运行，key 为运行槽持有者标识，用于 replace 时取消
+	running map[string]context.CancelCauseFunc
+
+	// syncMu 保证同一时刻只有一个 Sync 在对比并修改 entries
+	syncMu sync.Mutex
+	// manual 跟踪手动触发和补偿的运行，Stop 时等待其结束
+	manual sync.WaitGroup
+
+	// ctx 所有任务执行的根 context，停机等待超时后取消，通知任务协作退出
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     sync.WaitGroup
+}
+
+// entry 已注册到 cron 的任务，updatedAt 用于判断任务定义是否变化
+type entry struct {
+	id        cron.EntryID
+	updatedAt time.Time
+}
+
+func NewScheduler(c config.SchedulerConfig, repo *db.Repository, redisClient *redis.Client, w *writer.MultiWriter) *Scheduler {
+	loc, err := time.LoadLocation(c.Location)
+	if err != nil {
+		loc = time.Local
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		config:   c,
+		repo:     repo,
+		redis:    redisClient,
+		writer:   w,
+		cron:     cron.New(cron.WithLocation(loc)),
+		location: loc,
+		handlers: make(map[string]Route),
+		entries:  make(map[int64]entry),
+		caughtUp: make(map[int64]time.Time),
+		running:  make(map[string]context.CancelCauseFunc),
+		ctx:      ctx,
+		cancel:   cancel,
+		stop:     make(chan struct{}),
+	}
+	if c.LeaderElection {
+		s.elector = lock.NewElector(lock.NewLocker(redisClient, utils.InstanceID()), leaderKey, c.LeaseTTL)
+	}
+	return s
+}
+
+// AddHandlers 注册任务处理器，重名时后注册的覆盖先注册的
+func (s *Scheduler) AddHandlers(routes ...Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, route := range routes {
+		s.handlers[route.Name] = route
+	}
+}
+
+// HasHandler 处理器是否已注册
+func (s *Scheduler) HasHandler(name string) bool {
+	_, ok := s.handler(name)
+	return ok
+}
+
+// Lookup 按名称查询已注册的处理器
+func (s *Scheduler) Lookup(name string) (Route, bool) {
+	return s.handler(name)
+}
+
+// ValidateSpec 校验 cron 表达式（标准 5 段，支持 @every/@daily 等描述符）
+func ValidateSpec(spec string) error {
+	_, err := cron.ParseStandard(spec)
+	return err
+}
+
+// Start 加载已启用的任务并启动调度，同时监听任务定义变更并定期检查错过的调度
+// 需在所有处理器注册完成后调用，否则启动时补偿执行的任务会因处理器未注册而失败
+func (s *Scheduler) Start() {
+	if !s.config.Enabled {
+		return
+	}
+	if s.elector != nil {
+		// 首次竞选同步完成，启动时的补偿检查才能判断本实例是否为 leader
+		s.elector.Start()
+	}
+	if err := s.Sync(context.Background()); err != nil {
+		s.writer.Error("加载定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "Scheduler.Sync"),
+			writer.Field("username", "system"),
+			writer.Field("error", err.Error()),
+		)
+	}
+	s.cron.Start()
+	s.checkMisfires(context.Background())
+
+	s.done.Add(2)
+	go s.watchReload()
+	go s.pollReload()
+}
+
+// Stop 停止调度并等待正在执行的任务结束（可重复调用）
+// 等待超过 ShutdownTimeout 后取消任务 context，通知任务协作退出，再最多等待 ShutdownTimeout
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(s.shutdown)
+}
+
+func (s *Scheduler) shutdown() {
+	// 停止接收新的调度，正在等待重试的任务不再重试
+	close(s.stop)
+	s.done.Wait()
+	cronCtx := s.cron.Stop()
+
+	drained := make(chan struct{})
+	go func() {
+		<-cronCtx.Done()
+		s.manual.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(s.config.ShutdownTimeout):
+		logx.Errorf("等待定时任务结束超时（%s），取消正在执行的任务", s.config.ShutdownTimeout)
+		s.cancel()
+		select {
+		case <-drained:
+		case <-time.After(s.config.ShutdownTimeout):
+			logx.Errorf("定时任务未响应取消，放弃等待")
+		}
+	}
+	s.cancel()
+
+	if s.elector != nil {
+		s.elector.Stop()
+	}
+}
+
+func (s *Scheduler) handler(name string) (Route, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	route, ok := s.handlers[name]
+	return route, ok
+}
@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStopWithoutStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{"未调用 Start", true},
+		{"调度器未启用，Start 直接返回", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+			defer client.Close()
+			s := NewScheduler(config.SchedulerConfig{
+				Enabled:         tt.enabled,
+				LeaderElection:  true,
+				LeaseTTL:        3 * time.Second,
+				ShutdownTimeout: time.Second,
+			}, nil, client, nil)
+			if !tt.enabled {
+				s.Start()
+			}
+
+			stopped := make(chan struct{})
+			go func() {
+				s.Stop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(3 * time.Second):
+				t.Fatal("Stop 阻塞")
+			}
+		})
+	}
+}
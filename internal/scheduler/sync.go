@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-zero-template/internal/models"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// Sync 以数据库中已启用的任务为准，增量更新 cron 中的注册项
+// 新增或修改过的任务重新注册，已删除或暂停的任务移除，表达式非法的任务跳过并记录日志
+func (s *Scheduler) Sync(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	tasks, err := s.repo.CronTask.ListEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[int64]struct{}, len(tasks))
+	for i := range tasks {
+		task := tasks[i]
+		active[task.ID] = struct{}{}
+
+		s.mu.RLock()
+		current, ok := s.entries[task.ID]
+		s.mu.RUnlock()
+		if ok && current.updatedAt.Equal(task.UpdatedAt) {
+			continue
+		}
+		if ok {
+			s.unschedule(task.ID)
+		}
+		if err := s.schedule(task); err != nil {
+			s.writer.Error("注册定时任务失败",
+				writer.Field("log_type", "system"),
+				writer.Field("trace", "Scheduler."+task.Name),
+				writer.Field("username", "system"),
+				writer.Field("task_name", task.Name),
+				writer.Field("cron_expr", task.CronExpr),
+				writer.Field("error", err.Error()),
+			)
+		}
+	}
+
+	s.mu.RLock()
+	var removed []int64
+	for id := range s.entries {
+		if _, ok := active[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	s.mu.RUnlock()
+	for _, id := range removed {
+		s.unschedule(id)
+	}
+	return nil
+}
+
+// Reload 通知所有实例重新加载任务定义，Redis 不可用时仅重新加载本实例
+func (s *Scheduler) Reload(ctx context.Context) error {
+	if err := s.redis.Publish(ctx, reloadChannel, "reload").Err(); err != nil {
+		s.writer.Error("发布任务变更通知失败",
+			writer.Field("log_type", "redis"),
+			writer.Field("trace", "Scheduler.Reload"),
+			writer.Field("username", "system"),
+			writer.Field("error", err.Error()),
+		)
+		return s.Sync(ctx)
+	}
+	return nil
+}
+
+func (s *Scheduler) schedule(task models.CronTask) error {
+	entryID, err := s.cron.AddFunc(task.CronExpr, func() {
+		s.run(task)
+	})
+	if err != nil {
+		return fmt.Errorf("解析 cron 表达式失败: %w", err)
+	}
+
+	s.mu.Lock()
+	s.entries[task.ID] = entry{id: entryID, updatedAt: task.UpdatedAt}
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Scheduler) unschedule(taskID int64) {
+	s.mu.Lock()
+	current, ok := s.entries[taskID]
+	delete(s.entries, taskID)
+	delete(s.caughtUp, taskID)
+	s.mu.Unlock()
+	if ok {
+		s.cron.Remove(current.id)
+	}
+}
+
+// watchReload 订阅任务变更通知和取消运行通知
+func (s *Scheduler) watchReload() {
+	defer s.done.Done()
+
+	pubsub := s.redis.Subscribe(context.Background(), reloadChannel, cancelChannel)
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if msg.Channel == cancelChannel {
+				s.cancelRun(msg.Payload)
+				continue
+			}
+			s.syncAndLog()
+		}
+	}
+}
+
+// pollReload 定期全量对比，兜底处理丢失的变更通知，同时检查错过的调度
+func (s *Scheduler) pollReload() {
+	defer s.done.Done()
+
+	ticker := time.NewTicker(s.config.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.syncAndLog()
+			s.checkMisfires(context.Background())
+		}
+	}
+}
+
+func (s *Scheduler) syncAndLog() {
+	if err := s.Sync(context.Background()); err != nil {
+		s.writer.Error("重新加载定时任务失败",
+			writer.Field("log_type", "database"),
+			writer.Field("trace", "Scheduler.Sync"),
+			writer.Field("username", "system"),
+			writer.Field("error", err.Error()),
+		)
+	}
+}
@@ -3,6 +3,8 @@ package svc
 import (
 	"fmt"
 	"go-zero-template/internal/config"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/tracing"
 	"log"
 	"time"
 
@@ -48,9 +50,20 @@ func MustInitDB(pgConfig config.PostgresConfig) (*gorm.DB, string) {
 	if err != nil {
 		log.Fatalf("failed to connect database: %v", err)
 	}
+	// 链路追踪：请求内的 SQL 作为当前 span 的子 span
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		log.Fatalf("failed to register tracing plugin: %v", err)
+	}
 
 	// 自动迁移（先迁移被引用的表，再迁移引用表）
-	if err := db.AutoMigrate(); err != nil {
+	if err := db.AutoMigrate(
+		&models.CronTask{},
+		&models.CronJobRun{},
+		&models.WorkflowRun{},
+		&models.WorkflowNodeRun{},
+		&models.UserSyncRun{},
+		&models.UserSyncItem{},
+	); err != nil {
 		log.Fatalf("failed to auto migrate: %v", err)
 	}
 
@@ -4,11 +4,18 @@ import (
 	"go-zero-template/internal/config"
 	"go-zero-template/internal/db"
 	"go-zero-template/internal/middleware"
+	"go-zero-template/internal/request"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/usersync"
+	"go-zero-template/internal/utils"
+	"go-zero-template/internal/workflow"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/rest"
 
 	writer "github.com/zhengliu92/pg-log-writter"
+	"gorm.io/gorm"
 )
 
 type ServiceContext struct {
@@ -16,20 +23,69 @@ type ServiceContext struct {
 	Redis          *redis.Client
 	Repository     *db.Repository
 	Writer         *writer.MultiWriter
+	Scheduler      *scheduler.Scheduler
+	Workflow       *workflow.Runner
+	Services       *request.Registry          // 下游服务客户端注册表
+	UserService    *request.UserServiceClient // user_service 客户端
+	ProfileCache   *middleware.ProfileCache
+	UserSync       *usersync.Syncer
 	AuthMiddleware rest.Middleware
+	AdminGuard     rest.Middleware // 仅允许 utils.AdminGroup 访问，需放在 AuthMiddleware 之后
+
+	gormDB      *gorm.DB
+	pgxExecutor *PgxExecutor
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	gormDB, dsn := MustInitDB(c.Postgres)
 	redisClient := MustInitRedis(c.Redis)
 	repository := db.NewRepository(gormDB)
-	writer := MustInitWriter(dsn, gormDB)
+	writer, pgxExecutor := MustInitWriter(dsn, gormDB)
+	sched := scheduler.NewScheduler(c.Scheduler, repository, redisClient, writer)
+	workflowRunner := workflow.NewRunner(repository, writer, sched)
+	services := request.NewRegistry(c.Services, writer)
+	userService := services.UserService()
+	profileCache := middleware.NewProfileCache(c.Auth, redisClient, userService, writer)
 
 	return &ServiceContext{
 		Config:         c,
 		Redis:          redisClient,
 		Repository:     repository,
 		Writer:         writer,
-		AuthMiddleware: middleware.NewAuthMiddleware(&c.Services).Handle,
+		Scheduler:      sched,
+		Workflow:       workflowRunner,
+		Services:       services,
+		UserService:    userService,
+		ProfileCache:   profileCache,
+		UserSync:       usersync.NewSyncer(userService, repository, writer),
+		AuthMiddleware: middleware.NewAuthMiddleware(c.Auth, profileCache).Handle,
+		AdminGuard:     middleware.NewRoleGuardMiddleware(utils.AdminGroup, writer).Handle,
+		gormDB:         gormDB,
+		pgxExecutor:    pgxExecutor,
+	}
+}
+
+// Close 按依赖顺序释放 NewServiceContext 创建的资源：
+// 先停止调度器并等待任务结束，再刷新并关闭日志 Writer，最后关闭 PgxExecutor、Redis 和数据库连接池
+// Writer 关闭后无法再写日志，之后的错误只输出到 logx
+func (s *ServiceContext) Close() {
+	s.Scheduler.Stop()
+
+	if err := s.Writer.Close(); err != nil {
+		logx.Errorf("关闭日志 Writer 失败: %v", err)
+	}
+	if err := s.pgxExecutor.Close(); err != nil {
+		logx.Errorf("关闭 PgxExecutor 失败: %v", err)
+	}
+	if err := s.Redis.Close(); err != nil {
+		logx.Errorf("关闭 Redis 连接失败: %v", err)
+	}
+	sqlDB, err := s.gormDB.DB()
+	if err != nil {
+		logx.Errorf("获取数据库连接池失败: %v", err)
+		return
+	}
+	if err := sqlDB.Close(); err != nil {
+		logx.Errorf("关闭数据库连接池失败: %v", err)
 	}
 }
@@ -8,7 +8,8 @@ import (
 	"gorm.io/gorm"
 )
 
-func MustInitWriter(dsn string, gormDB *gorm.DB) *writer.MultiWriter {
+// MustInitWriter 创建日志 Writer，同时返回其使用的 PgxExecutor，供停机时关闭连接池
+func MustInitWriter(dsn string, gormDB *gorm.DB) (*writer.MultiWriter, *PgxExecutor) {
 	writerConfig := &writer.PostgresConfig{
 		TableName:     "logs",
 		BufferSize:    100,
@@ -23,5 +24,5 @@ func MustInitWriter(dsn string, gormDB *gorm.DB) *writer.MultiWriter {
 		log.Fatalf("failed to create pg writer: %v", err)
 	}
 	consoleWriter := writer.NewConsoleWriter()
-	return writer.NewMultiWriter(pgWriter, consoleWriter)
+	return writer.NewMultiWriter(pgWriter, consoleWriter), pgxExecutor
 }
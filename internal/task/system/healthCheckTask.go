@@ -0,0 +1,25 @@
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"go-zero-template/internal/logic/system"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/svc"
+)
+
+// HealthCheckTask 定时执行健康检查
+func HealthCheckTask(svcCtx *svc.ServiceContext) scheduler.Handler {
+	return func(ctx context.Context) error {
+		l := system.NewHealthLogic(ctx, svcCtx)
+		resp, err := l.Health()
+		if err != nil {
+			return err
+		}
+		if resp.Status != "ok" {
+			return fmt.Errorf("服务状态异常: %s", resp.Status)
+		}
+		return nil
+	}
+}
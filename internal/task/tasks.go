@@ -0,0 +1,31 @@
+package task
+
+import (
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/svc"
+	system "go-zero-template/internal/task/system"
+	user "go-zero-template/internal/task/user"
+)
+
+// RegisterTasks 注册定时任务处理器，名称需与 cron_tasks.handler 一致
+func RegisterTasks(s *scheduler.Scheduler, serverCtx *svc.ServiceContext) {
+	s.AddHandlers(
+		[]scheduler.Route{
+			{
+				// 健康检查
+				Name:    "HealthCheck",
+				Handler: system.HealthCheckTask(serverCtx),
+			},
+			{
+				// 上游员工同步到 user_service
+				Name:    "UserSync",
+				Handler: user.UserSyncTask(serverCtx, false),
+			},
+			{
+				// 上游员工同步演练，只生成差异报告
+				Name:    "UserSyncDryRun",
+				Handler: user.UserSyncTask(serverCtx, true),
+			},
+		}...,
+	)
+}
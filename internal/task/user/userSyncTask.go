@@ -0,0 +1,24 @@
+package user
+
+import (
+	"context"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/svc"
+	"go-zero-template/internal/usersync"
+)
+
+// UserSyncTask 从上游（Services 中的 user_sync_source）拉取员工并同步到 user_service，dryRun 时只生成差异报告不写入
+// 上游使用独立的客户端，上游故障不会打开 user_service 的熔断器；未配置 user_sync_source 时任务失败且不重试
+func UserSyncTask(svcCtx *svc.ServiceContext, dryRun bool) scheduler.Handler {
+	client, clientErr := svcCtx.Services.Client(config.UserSyncSourceName)
+	return func(ctx context.Context) error {
+		if clientErr != nil {
+			return scheduler.Permanent(clientErr)
+		}
+		source := usersync.NewHTTPSource(svcCtx.Config.UserSync.SourceName, client)
+		_, err := svcCtx.UserSync.Run(ctx, source, dryRun)
+		return err
+	}
+}
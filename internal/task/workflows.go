@@ -0,0 +1,26 @@
+package task
+
+import (
+	"go-zero-template/internal/workflow"
+)
+
+// RegisterWorkflows 注册工作流，节点处理器需已在 RegisterTasks 中注册
+// 工作流以 workflow.HandlerName(名称) 作为处理器名称，在 cron_tasks 中配置后按计划运行
+// 使用示例（夜间同步：组织同步完成后同步用户，用户同步完成后生成报表，任一步失败时后续节点跳过）：
+//
+//	r.MustRegister(workflow.Definition{
+//		Name:        "NightlySync",
+//		Description: "夜间同步",
+//		Nodes: []workflow.Node{
+//			{Name: "org", Handler: "OrgSync"},
+//			{Name: "user", Handler: "UserSync", DependsOn: []string{"org"}},
+//			{Name: "report", Handler: "UserReport", DependsOn: []string{"user"}, Timeout: 10 * time.Minute},
+//		},
+//	})
+//
+// 对应的 cron_tasks 记录：handler 为 workflow:NightlySync，cron_expr 如 0 2 * * *
+func RegisterWorkflows(r *workflow.Runner) {
+	r.MustRegister(
+		[]workflow.Definition{}...,
+	)
+}
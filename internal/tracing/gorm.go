@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"errors"
+
+	"github.com/zeromicro/go-zero/core/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey span 在 gorm.DB 实例中的 key
+const gormSpanKey = "tracing:span"
+
+// rowsAffectedKey 影响行数，semconv v1.4.0 未定义
+var rowsAffectedKey = attribute.Key("db.rows_affected")
+
+// gormPlugin 为每条 SQL 创建 client span，仅在 ctx 中已有 span 时创建（调度器轮询等后台查询不产生根 span）
+// Repository 需通过 WithContext(ctx) 传入请求的 ctx
+type gormPlugin struct{}
+
+// NewGormPlugin 创建 GORM 链路追踪插件，通过 db.Use 注册
+func NewGormPlugin() gorm.Plugin {
+	return gormPlugin{}
+}
+
+func (gormPlugin) Name() string {
+	return "tracing"
+}
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", beforeQuery("INSERT")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", afterQuery),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", beforeQuery("SELECT")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", afterQuery),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", beforeQuery("UPDATE")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", afterQuery),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", beforeQuery("DELETE")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", afterQuery),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", beforeQuery("ROW")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", afterQuery),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", beforeQuery("RAW")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", afterQuery),
+	)
+}
+
+// beforeQuery 创建 span，名称为 "<操作> <表名>"，如 SELECT cron_tasks
+func beforeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !oteltrace.SpanContextFromContext(ctx).IsValid() {
+			return
+		}
+		name := operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		_, span := trace.TracerFromContext(ctx).Start(ctx, name,
+			oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+			oteltrace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBOperationKey.String(operation),
+				semconv.DBSQLTableKey.String(db.Statement.Table),
+			),
+		)
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+// afterQuery 记录 SQL（参数化，不含参数值）和结果并结束 span，记录不存在不视为错误
+func afterQuery(db *gorm.DB) {
+	v, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(oteltrace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBStatementKey.String(db.Statement.SQL.String()),
+		semconv.DBSQLTableKey.String(db.Statement.Table),
+		rowsAffectedKey.Int64(db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,21 @@
+// Package tracing 链路追踪辅助：GORM 查询 span、日志 Writer 的 trace_id、span_id 字段
+// TracerProvider 由 go-zero 按 RestConf.Telemetry 初始化，HTTP 请求的 server span 由 rest 框架创建
+package tracing
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/trace"
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// TraceIDField 当前链路写入日志 Writer 的 trace_id 列，ctx 中没有 span 时为空字符串
+// 与 trace 列（Module.Method）分开，可按 trace_id 查询同一链路的所有日志
+func TraceIDField(ctx context.Context) writer.FieldT {
+	return writer.Field("trace_id", trace.TraceIDFromContext(ctx))
+}
+
+// SpanIDField 当前 span 写入日志 Writer 的 span_id 列，ctx 中没有 span 时为空字符串
+func SpanIDField(ctx context.Context) writer.FieldT {
+	return writer.Field("span_id", trace.SpanIDFromContext(ctx))
+}
@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestIDFields(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+	sc := span.SpanContext()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantTraceID string
+		wantSpanID  string
+	}{
+		{"ctx 中有 span", ctx, sc.TraceID().String(), sc.SpanID().String()},
+		{"ctx 中没有 span", context.Background(), "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if f := TraceIDField(tt.ctx); f.Key != "trace_id" || f.Value != tt.wantTraceID {
+				t.Fatalf("TraceIDField = %s: %v, want trace_id: %q", f.Key, f.Value, tt.wantTraceID)
+			}
+			if f := SpanIDField(tt.ctx); f.Key != "span_id" || f.Value != tt.wantSpanID {
+				t.Fatalf("SpanIDField = %s: %v, want span_id: %q", f.Key, f.Value, tt.wantSpanID)
+			}
+		})
+	}
+}
@@ -31,8 +31,9 @@ func (r *BaseResponse[T]) Err() error {
 
 // ResponseError 业务错误，携带 code 与 msg
 type ResponseError struct {
-	Code int
-	Msg  string
+	Code       int
+	Msg        string
+	HTTPStatus int // 上游 HTTP 状态码，0 表示未知
 }
 
 func (e *ResponseError) Error() string {
@@ -50,15 +51,3 @@ func ParseBaseResponse[T any](data []byte) (*BaseResponse[T], error) {
 	}
 	return &out, nil
 }
-
-// ParseBaseResponseFromAny 从 Request 返回的 any（如 map[string]any）解析为 BaseResponse[T]
-func ParseBaseResponseFromAny[T any](data any) (*BaseResponse[T], error) {
-	if data == nil {
-		return &BaseResponse[T]{}, nil
-	}
-	bytes, err := json.Marshal(data)
-	if err != nil {
-		return nil, fmt.Errorf("序列化响应失败: %w", err)
-	}
-	return ParseBaseResponse[T](bytes)
-}
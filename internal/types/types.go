@@ -18,3 +18,301 @@ type PingUserServiceResponse struct {
 	Name          string `json:"name"`            // 用户姓名
 	SAPEmployeeID int    `json:"sap_employee_id"` // SAP 员工编号
 }
+
+type CronJobRun struct {
+	ID           int64  `json:"id"`            // 运行记录ID
+	TaskID       int64  `json:"task_id"`       // 任务ID
+	TaskName     string `json:"task_name"`     // 任务名称
+	Handler      string `json:"handler"`       // 处理器名称
+	Trigger      string `json:"trigger"`       // 触发方式: schedule-按计划调度, manual-手动执行, redrive-死信重新投递, catchup-补偿错过的调度
+	Status       string `json:"status"`        // 运行状态: running-运行中, success-成功, failed-失败, timeout-超时, dead_letter-重试耗尽或被停机打断后进入死信, redriven-死信已重新投递, skipped-上一次运行未结束而跳过, canceled-被新的运行取消
+	Attempts     int    `json:"attempts"`      // 已尝试次数（含首次）
+	RedriveOf    int64  `json:"redrive_of"`    // 重新投递来源的死信运行记录ID，0 表示非重新投递
+	ScheduledAt  string `json:"scheduled_at"`  // 计划触发时间（RFC3339），仅 schedule、catchup 运行有值
+	StartedAt    string `json:"started_at"`    // 开始时间（RFC3339）
+	FinishedAt   string `json:"finished_at"`   // 结束时间（RFC3339），运行中为空
+	DurationMs   int64  `json:"duration_ms"`   // 耗时（毫秒）
+	Error        string `json:"error"`         // 错误信息，成功时为空
+	Instance     string `json:"instance"`      // 执行实例标识
+	FencingToken int64  `json:"fencing_token"` // 执行时的 leader fencing token，手动执行和重新投递为 0
+}
+
+type ListCronJobRunsRequest struct {
+	Page      int    `form:"page,default=1,range=[1:]"`                                                                    // 页码，从 1 开始
+	PageSize  int    `form:"page_size,default=20,range=[1:100]"`                                                           // 每页数量，最大 100
+	TaskID    int64  `form:"task_id,optional"`                                                                             // 任务ID
+	TaskName  string `form:"task_name,optional"`                                                                           // 任务名称
+	Trigger   string `form:"trigger,optional,options=schedule|manual|redrive|catchup"`                                     // 触发方式: schedule-按计划调度, manual-手动执行, redrive-死信重新投递, catchup-补偿错过的调度
+	Status    string `form:"status,optional,options=running|success|failed|timeout|dead_letter|redriven|skipped|canceled"` // 运行状态: running-运行中, success-成功, failed-失败, timeout-超时, dead_letter-死信, redriven-死信已重新投递, skipped-上一次运行未结束而跳过, canceled-被新的运行取消
+	StartFrom string `form:"start_from,optional"`                                                                          // 开始时间下限（RFC3339，包含）
+	StartTo   string `form:"start_to,optional"`                                                                            // 开始时间上限（RFC3339，不包含）
+}
+
+type ListCronJobRunsResponse struct {
+	Total int64        `json:"total"` // 总数
+	List  []CronJobRun `json:"list"`  // 运行记录列表
+}
+
+type GetCronJobRunRequest struct {
+	ID int64 `path:"id"` // 运行记录ID
+}
+
+type GetCronJobRunResponse struct {
+	Run CronJobRun `json:"run"` // 运行记录
+}
+
+type RedriveCronJobRunRequest struct {
+	ID int64 `path:"id"` // 死信运行记录ID
+}
+
+type RedriveCronJobRunResponse struct {
+	RunID int64 `json:"run_id"` // 新的运行记录ID
+}
+
+type CronTask struct {
+	ID                int64   `json:"id"`                 // 任务ID
+	Name              string  `json:"name"`               // 任务名称（唯一）
+	CronExpr          string  `json:"cron_expr"`          // cron 表达式（标准 5 段，如 "0 2 * * *"，支持 "@every 5m"、"@daily" 等描述符）
+	Handler           string  `json:"handler"`            // 处理器名称，需为已注册的处理器
+	Enabled           *bool   `json:"enabled"`            // 是否启用
+	Timeout           int     `json:"timeout"`            // 单次执行超时时间（秒），0 表示不限制
+	MaxAttempts       int     `json:"max_attempts"`       // 总尝试次数（含首次），<= 1 表示不重试
+	RetryBackoff      int     `json:"retry_backoff"`      // 首次重试前的等待时间（秒），之后每次翻倍
+	RetryBackoffMax   int     `json:"retry_backoff_max"`  // 单次重试等待时间上限（秒），0 表示不限制
+	RetryJitter       float64 `json:"retry_jitter"`       // 重试等待时间的随机抖动比例（0~1）
+	RetryOnTimeout    *bool   `json:"retry_on_timeout"`   // 超时是否重试
+	MisfirePolicy     string  `json:"misfire_policy"`     // 错过调度的补偿策略: skip-跳过, fire_once-补执行一次, fire_all-逐个补执行（最多 max_catchup 次）
+	MaxCatchup        int     `json:"max_catchup"`        // fire_all 时最多补执行的次数，0 表示使用调度器默认值
+	ConcurrencyPolicy string  `json:"concurrency_policy"` // 并发策略（作用于所有触发方式）: allow-允许并发, forbid-上一次运行未结束时跳过（手动执行和重新投递返回 409）, replace-取消上一次运行
+	Description       string  `json:"description"`        // 任务描述
+	CreatedAt         string  `json:"created_at"`         // 创建时间（RFC3339）
+	UpdatedAt         string  `json:"updated_at"`         // 更新时间（RFC3339）
+}
+
+type ListCronTasksRequest struct {
+	Page     int    `form:"page,default=1,range=[1:]"`          // 页码，从 1 开始
+	PageSize int    `form:"page_size,default=20,range=[1:100]"` // 每页数量，最大 100
+	Name     string `form:"name,optional"`                      // 任务名称（模糊匹配）
+	Handler  string `form:"handler,optional"`                   // 处理器名称
+	Enabled  *bool  `form:"enabled,optional"`                   // 是否启用
+}
+
+type ListCronTasksResponse struct {
+	Total int64      `json:"total"` // 总数
+	List  []CronTask `json:"list"`  // 任务列表
+}
+
+type GetCronTaskRequest struct {
+	ID int64 `path:"id"` // 任务ID
+}
+
+type GetCronTaskResponse struct {
+	Task CronTask `json:"task"` // 任务
+}
+
+type CreateCronTaskRequest struct {
+	Name              string  `json:"name"`                                                          // 任务名称（唯一）
+	CronExpr          string  `json:"cron_expr"`                                                     // cron 表达式
+	Handler           string  `json:"handler"`                                                       // 处理器名称
+	Enabled           *bool   `json:"enabled,optional"`                                              // 是否启用，默认 true
+	Timeout           int     `json:"timeout,optional,range=[0:]"`                                   // 单次执行超时时间（秒），0 表示不限制
+	MaxAttempts       int     `json:"max_attempts,default=1,range=[1:100]"`                          // 总尝试次数（含首次），1 表示不重试
+	RetryBackoff      int     `json:"retry_backoff,default=10,range=[0:]"`                           // 首次重试前的等待时间（秒），之后每次翻倍
+	RetryBackoffMax   int     `json:"retry_backoff_max,default=300,range=[0:]"`                      // 单次重试等待时间上限（秒），0 表示不限制
+	RetryJitter       float64 `json:"retry_jitter,default=0.2,range=[0:1]"`                          // 重试等待时间的随机抖动比例（0~1）
+	RetryOnTimeout    *bool   `json:"retry_on_timeout,optional"`                                     // 超时是否重试，默认 true
+	MisfirePolicy     string  `json:"misfire_policy,default=skip,options=skip|fire_once|fire_all"`   // 错过调度的补偿策略: skip-跳过, fire_once-补执行一次, fire_all-逐个补执行（最多 max_catchup 次）
+	MaxCatchup        int     `json:"max_catchup,optional,range=[0:1000]"`                           // fire_all 时最多补执行的次数，0 表示使用调度器默认值
+	ConcurrencyPolicy string  `json:"concurrency_policy,default=allow,options=allow|forbid|replace"` // 并发策略（作用于所有触发方式）: allow-允许并发, forbid-上一次运行未结束时跳过（手动执行和重新投递返回 409）, replace-取消上一次运行
+	Description       string  `json:"description,optional"`                                          // 任务描述
+}
+
+type CreateCronTaskResponse struct {
+	Task CronTask `json:"task"` // 任务
+}
+
+type UpdateCronTaskRequest struct {
+	ID                int64    `path:"id"`                                                       // 任务ID
+	Name              *string  `json:"name,optional"`                                            // 任务名称（唯一）
+	CronExpr          *string  `json:"cron_expr,optional"`                                       // cron 表达式
+	Handler           *string  `json:"handler,optional"`                                         // 处理器名称
+	Enabled           *bool    `json:"enabled,optional"`                                         // 是否启用
+	Timeout           *int     `json:"timeout,optional,range=[0:]"`                              // 单次执行超时时间（秒），0 表示不限制
+	MaxAttempts       *int     `json:"max_attempts,optional,range=[1:100]"`                      // 总尝试次数（含首次），1 表示不重试
+	RetryBackoff      *int     `json:"retry_backoff,optional,range=[0:]"`                        // 首次重试前的等待时间（秒）
+	RetryBackoffMax   *int     `json:"retry_backoff_max,optional,range=[0:]"`                    // 单次重试等待时间上限（秒），0 表示不限制
+	RetryJitter       *float64 `json:"retry_jitter,optional,range=[0:1]"`                        // 重试等待时间的随机抖动比例（0~1）
+	RetryOnTimeout    *bool    `json:"retry_on_timeout,optional"`                                // 超时是否重试
+	MisfirePolicy     *string  `json:"misfire_policy,optional,options=skip|fire_once|fire_all"`  // 错过调度的补偿策略: skip-跳过, fire_once-补执行一次, fire_all-逐个补执行
+	MaxCatchup        *int     `json:"max_catchup,optional,range=[0:1000]"`                      // fire_all 时最多补执行的次数，0 表示使用调度器默认值
+	ConcurrencyPolicy *string  `json:"concurrency_policy,optional,options=allow|forbid|replace"` // 并发策略（作用于所有触发方式）: allow-允许并发, forbid-上一次运行未结束时跳过（手动执行和重新投递返回 409）, replace-取消上一次运行
+	Description       *string  `json:"description,optional"`                                     // 任务描述
+}
+
+type UpdateCronTaskResponse struct {
+	Task CronTask `json:"task"` // 任务
+}
+
+type PauseCronTaskRequest struct {
+	ID int64 `path:"id"` // 任务ID
+}
+
+type PauseCronTaskResponse struct {
+	Task CronTask `json:"task"` // 任务
+}
+
+type ResumeCronTaskRequest struct {
+	ID int64 `path:"id"` // 任务ID
+}
+
+type ResumeCronTaskResponse struct {
+	Task CronTask `json:"task"` // 任务
+}
+
+type DeleteCronTaskRequest struct {
+	ID int64 `path:"id"` // 任务ID
+}
+
+type DeleteCronTaskResponse struct {
+}
+
+type RunCronTaskRequest struct {
+	ID int64 `path:"id"` // 任务ID
+}
+
+type RunCronTaskResponse struct {
+	RunID int64 `json:"run_id"` // 运行记录ID，可通过运行记录接口查询执行结果
+}
+
+type WorkflowNode struct {
+	Name      string   `json:"name"`       // 节点名称
+	Handler   string   `json:"handler"`    // 处理器名称
+	DependsOn []string `json:"depends_on"` // 上游节点名称，所有上游成功后才执行
+	Timeout   int      `json:"timeout"`    // 节点执行超时时间（秒），0 表示不限制
+}
+
+type Workflow struct {
+	Name        string         `json:"name"`        // 工作流名称
+	Handler     string         `json:"handler"`     // 注册到调度器的处理器名称，在定时任务中使用该处理器即可按计划运行
+	Description string         `json:"description"` // 工作流描述
+	Nodes       []WorkflowNode `json:"nodes"`       // 节点列表
+}
+
+type WorkflowRun struct {
+	ID         int64  `json:"id"`          // 工作流运行记录ID
+	Workflow   string `json:"workflow"`    // 工作流名称
+	Status     string `json:"status"`      // 运行状态: running-运行中, success-全部节点成功, failed-存在未成功的节点
+	StartedAt  string `json:"started_at"`  // 开始时间（RFC3339）
+	FinishedAt string `json:"finished_at"` // 结束时间（RFC3339），运行中为空
+	DurationMs int64  `json:"duration_ms"` // 耗时（毫秒）
+	Error      string `json:"error"`       // 错误信息，成功时为空
+	Instance   string `json:"instance"`    // 执行实例标识
+}
+
+type WorkflowNodeRun struct {
+	ID         int64    `json:"id"`          // 节点运行记录ID
+	Node       string   `json:"node"`        // 节点名称
+	Handler    string   `json:"handler"`     // 处理器名称
+	DependsOn  []string `json:"depends_on"`  // 上游节点名称
+	Status     string   `json:"status"`      // 运行状态: pending-等待上游, running-运行中, success-成功, failed-失败, timeout-超时, skipped-上游未成功而跳过, canceled-工作流被取消
+	StartedAt  string   `json:"started_at"`  // 开始时间（RFC3339），未开始为空
+	FinishedAt string   `json:"finished_at"` // 结束时间（RFC3339），未结束为空
+	DurationMs int64    `json:"duration_ms"` // 耗时（毫秒）
+	Error      string   `json:"error"`       // 错误信息或跳过原因
+}
+
+type ListWorkflowsRequest struct {
+}
+
+type ListWorkflowsResponse struct {
+	List []Workflow `json:"list"` // 工作流列表
+}
+
+type ListWorkflowRunsRequest struct {
+	Page      int    `form:"page,default=1,range=[1:]"`                      // 页码，从 1 开始
+	PageSize  int    `form:"page_size,default=20,range=[1:100]"`             // 每页数量，最大 100
+	Workflow  string `form:"workflow,optional"`                              // 工作流名称
+	Status    string `form:"status,optional,options=running|success|failed"` // 运行状态: running-运行中, success-成功, failed-失败
+	StartFrom string `form:"start_from,optional"`                            // 开始时间下限（RFC3339，包含）
+	StartTo   string `form:"start_to,optional"`                              // 开始时间上限（RFC3339，不包含）
+}
+
+type ListWorkflowRunsResponse struct {
+	Total int64         `json:"total"` // 总数
+	List  []WorkflowRun `json:"list"`  // 工作流运行记录列表
+}
+
+type GetWorkflowRunRequest struct {
+	ID int64 `path:"id"` // 工作流运行记录ID
+}
+
+type GetWorkflowRunResponse struct {
+	Run   WorkflowRun       `json:"run"`   // 工作流运行记录
+	Nodes []WorkflowNodeRun `json:"nodes"` // 节点运行记录
+}
+
+type UserSyncRun struct {
+	ID         int64  `json:"id"`          // 同步运行记录ID
+	Source     string `json:"source"`      // 上游数据源名称
+	DryRun     *bool  `json:"dry_run"`     // 是否为演练（只对比不写入）
+	Status     string `json:"status"`      // 运行状态: running-运行中, success-成功, failed-失败（拉取数据失败或存在失败用户）
+	Total      int    `json:"total"`       // 上游记录数
+	Created    int    `json:"created"`     // 创建数（演练时为待创建数）
+	Updated    int    `json:"updated"`     // 更新数（演练时为待更新数）
+	Skipped    int    `json:"skipped"`     // 无差异跳过数
+	Failed     int    `json:"failed"`      // 失败数
+	StartedAt  string `json:"started_at"`  // 开始时间（RFC3339）
+	FinishedAt string `json:"finished_at"` // 结束时间（RFC3339），运行中为空
+	DurationMs int64  `json:"duration_ms"` // 耗时（毫秒）
+	Error      string `json:"error"`       // 错误信息，成功时为空
+}
+
+type UserSyncItem struct {
+	ID        int64  `json:"id"`         // 明细ID
+	LoginName string `json:"login_name"` // 登录名
+	UserID    int    `json:"user_id"`    // user_service 用户ID，待创建时为 0
+	Action    string `json:"action"`     // 动作: create-创建, update-更新, failed-失败
+	Changes   string `json:"changes"`    // 字段差异（JSON），key 为字段名，value 为 {"old": ..., "new": ...}
+	Error     string `json:"error"`      // 错误信息
+	CreatedAt string `json:"created_at"` // 创建时间（RFC3339）
+}
+
+type ListUserSyncRunsRequest struct {
+	Page     int    `form:"page,default=1,range=[1:]"`                      // 页码，从 1 开始
+	PageSize int    `form:"page_size,default=20,range=[1:100]"`             // 每页数量，最大 100
+	Source   string `form:"source,optional"`                                // 上游数据源名称
+	Status   string `form:"status,optional,options=running|success|failed"` // 运行状态: running-运行中, success-成功, failed-失败
+	DryRun   *bool  `form:"dry_run,optional"`                               // 是否为演练
+}
+
+type ListUserSyncRunsResponse struct {
+	Total int64         `json:"total"` // 总数
+	List  []UserSyncRun `json:"list"`  // 同步运行记录列表
+}
+
+type GetUserSyncRunRequest struct {
+	ID int64 `path:"id"` // 同步运行记录ID
+}
+
+type GetUserSyncRunResponse struct {
+	Run UserSyncRun `json:"run"` // 同步运行记录
+}
+
+type ListUserSyncItemsRequest struct {
+	ID       int64  `path:"id"`                                           // 同步运行记录ID
+	Page     int    `form:"page,default=1,range=[1:]"`                    // 页码，从 1 开始
+	PageSize int    `form:"page_size,default=20,range=[1:100]"`           // 每页数量，最大 100
+	Action   string `form:"action,optional,options=create|update|failed"` // 动作: create-创建, update-更新, failed-失败
+}
+
+type ListUserSyncItemsResponse struct {
+	Total int64          `json:"total"` // 总数
+	List  []UserSyncItem `json:"list"`  // 明细列表
+}
+
+type InvalidateUserProfileCacheRequest struct {
+	UserID int `path:"user_id"` // user_service 用户ID
+}
+
+type InvalidateUserProfileCacheResponse struct {
+}
@@ -0,0 +1,44 @@
+package usersync
+
+import (
+	"reflect"
+	"strings"
+
+	"go-zero-template/internal/types"
+)
+
+// Change 单个字段的差异
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// diffUser 按 json 字段名对比 user_service 中的用户与上游记录，返回有差异的字段
+// 指针字段比较指向的值，nil 与非 nil 视为不同
+func diffUser(current, desired types.UserBase) map[string]Change {
+	changes := make(map[string]Change)
+	cv := reflect.ValueOf(current)
+	dv := reflect.ValueOf(desired)
+	t := cv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		oldValue, newValue := deref(cv.Field(i)), deref(dv.Field(i))
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes[name] = Change{Old: oldValue, New: newValue}
+		}
+	}
+	return changes
+}
+
+func deref(v reflect.Value) any {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	}
+	return v.Interface()
+}
@@ -0,0 +1,50 @@
+package usersync
+
+import (
+	"context"
+	"net/http"
+
+	"go-zero-template/internal/request"
+)
+
+// Record 上游员工记录，LoginName 为匹配 user_service 用户的唯一键，Password 仅在创建用户时使用
+type Record = request.CreateUserRequest
+
+// Source 上游数据源
+type Source interface {
+	// Name 数据源名称，记录在同步运行记录中
+	Name() string
+	// Fetch 拉取全量员工记录
+	Fetch(ctx context.Context) ([]Record, error)
+}
+
+// httpSourceData 上游接口返回的 data 结构
+type httpSourceData struct {
+	List []Record `json:"list"`
+}
+
+// HTTPSource 通过上游服务的 RequestClient 拉取员工记录，请求地址为服务的基础地址，响应格式为 BaseResponse，data.list 为记录列表
+type HTTPSource struct {
+	name   string
+	client *request.RequestClient
+}
+
+func NewHTTPSource(name string, client *request.RequestClient) *HTTPSource {
+	return &HTTPSource{
+		name:   name,
+		client: client,
+	}
+}
+
+func (s *HTTPSource) Name() string {
+	return s.name
+}
+
+// Fetch 按上游服务的认证方式（AuthMode）拉取全量员工记录
+func (s *HTTPSource) Fetch(ctx context.Context) ([]Record, error) {
+	resp, err := request.DoSystem[httpSourceData](ctx, s.client, http.MethodGet, s.client.BaseURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body.Data.List, nil
+}
@@ -0,0 +1,53 @@
+package usersync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/request"
+	"go-zero-template/internal/types"
+)
+
+func TestHTTPSourceFetch(t *testing.T) {
+	var gotPath, gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(types.BaseResponse[httpSourceData]{
+			Code: 200,
+			Data: httpSourceData{List: []Record{{UserBase: types.UserBase{LoginName: "bob", Name: "Bob"}}}},
+		})
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+	services := config.ServicesConfig{
+		config.UserServiceName:    {Scheme: "http", Host: u.Hostname(), Port: port, Path: "/api/v1/user", AuthMode: config.AuthModeToken},
+		config.UserSyncSourceName: {Scheme: "http", Host: u.Hostname(), Port: port, Path: "/api/v1/employees", AuthMode: config.AuthModeNone},
+	}
+	registry := request.NewRegistry(services, nil)
+	client := registry.MustClient(config.UserSyncSourceName)
+	if client == registry.UserService().RequestClient {
+		t.Fatal("上游数据源与 user_service 共用客户端")
+	}
+
+	records, err := NewHTTPSource("upstream", client).Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(records) != 1 || records[0].LoginName != "bob" {
+		t.Fatalf("records = %+v", records)
+	}
+	if gotPath != "/api/v1/employees" {
+		t.Fatalf("请求路径 = %q, want /api/v1/employees", gotPath)
+	}
+	if gotAuth != "" {
+		t.Fatalf("AuthMode 为 none 时携带了 Authorization: %q", gotAuth)
+	}
+}
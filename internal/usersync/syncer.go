@@ -0,0 +1,208 @@
+package usersync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-zero-template/internal/db"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/request"
+	"go-zero-template/internal/types"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// Syncer 将上游员工记录 upsert 到 user_service：按登录名查询，不存在则创建，存在且有差异则更新
+// 每次同步的数量汇总和差异明细写入数据库
+type Syncer struct {
+	client *request.UserServiceClient
+	repo   *db.Repository
+	writer *writer.MultiWriter
+}
+
+func NewSyncer(client *request.UserServiceClient, repo *db.Repository, w *writer.MultiWriter) *Syncer {
+	return &Syncer{
+		client: client,
+		repo:   repo,
+		writer: w,
+	}
+}
+
+// Run 执行一次同步，dryRun 时只生成差异报告不写入 user_service
+// 单个用户失败不中断同步；拉取数据失败、同步被取消或存在失败用户时返回 error
+func (s *Syncer) Run(ctx context.Context, source Source, dryRun bool) (*models.UserSyncRun, error) {
+	const trace = "UserSync.Run"
+
+	run := &models.UserSyncRun{
+		Source:    source.Name(),
+		DryRun:    &dryRun,
+		Status:    models.RunStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.repo.UserSyncRun.Create(context.Background(), run); err != nil {
+		s.logDBError("UserSyncRun.Create", source.Name(), err)
+		return nil, err
+	}
+
+	var items []*models.UserSyncItem
+	runErr := func() error {
+		// 预先登录系统账号，登录失败时不再逐个请求；之后的请求使用 SystemAuth，token 过期或被拒绝时自动刷新
+		if _, err := s.client.SystemToken(ctx); err != nil {
+			return fmt.Errorf("获取 user_service token 失败: %w", err)
+		}
+		records, err := source.Fetch(ctx)
+		if err != nil {
+			return fmt.Errorf("拉取上游数据失败: %w", err)
+		}
+
+		run.Total = len(records)
+		for i := range records {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("同步被取消: %w", err)
+			}
+			item := s.syncOne(ctx, run, &records[i], dryRun)
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+		return nil
+	}()
+
+	if err := s.repo.UserSyncItem.CreateBatch(context.Background(), items); err != nil {
+		s.logDBError("UserSyncItem.CreateBatch", source.Name(), err)
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(run.StartedAt).Milliseconds()
+	run.Status = models.RunStatusSuccess
+	if runErr == nil && run.Failed > 0 {
+		runErr = fmt.Errorf("%d 个用户同步失败", run.Failed)
+	}
+	if runErr != nil {
+		run.Status = models.RunStatusFailed
+		run.Error = runErr.Error()
+	}
+	if err := s.repo.UserSyncRun.Finish(context.Background(), run); err != nil {
+		s.logDBError("UserSyncRun.Finish", source.Name(), err)
+	}
+
+	if runErr != nil {
+		s.writer.Error("用户同步失败",
+			writer.Field("log_type", "system"),
+			writer.Field("trace", trace),
+			writer.Field("username", "system"),
+			writer.Field("sync_run_id", run.ID),
+			writer.Field("source", run.Source),
+			writer.Field("dry_run", dryRun),
+			writer.Field("total", run.Total),
+			writer.Field("created", run.Created),
+			writer.Field("updated", run.Updated),
+			writer.Field("skipped", run.Skipped),
+			writer.Field("failed", run.Failed),
+			writer.Field("error", runErr.Error()),
+		)
+		return run, runErr
+	}
+	s.writer.Info("用户同步完成",
+		writer.Field("log_type", "system"),
+		writer.Field("trace", trace),
+		writer.Field("username", "system"),
+		writer.Field("sync_run_id", run.ID),
+		writer.Field("source", run.Source),
+		writer.Field("dry_run", dryRun),
+		writer.Field("total", run.Total),
+		writer.Field("created", run.Created),
+		writer.Field("updated", run.Updated),
+		writer.Field("skipped", run.Skipped),
+	)
+	return run, nil
+}
+
+// syncOne 同步单个用户并累加数量，无差异时返回 nil（只计数不记录明细）
+func (s *Syncer) syncOne(ctx context.Context, run *models.UserSyncRun, record *Record, dryRun bool) *models.UserSyncItem {
+	item := &models.UserSyncItem{
+		RunID:     run.ID,
+		LoginName: record.LoginName,
+	}
+	if record.LoginName == "" {
+		return s.failItem(run, item, errors.New("登录名为空"))
+	}
+
+	existing, err := s.client.GetUserByLoginName(ctx, request.SystemAuth, record.LoginName)
+
+	var respErr *types.ResponseError
+	is404 := errors.As(err, &respErr) && respErr.Code == http.StatusNotFound
+
+	if err != nil && !is404 {
+		return s.failItem(run, item, fmt.Errorf("查询用户失败: %w", err))
+	}
+
+	if existing == nil || is404 {
+		item.Action = models.UserSyncActionCreate
+		item.Changes = marshalChanges(diffUser(types.UserBase{}, record.UserBase))
+		if !dryRun {
+			created, err := s.client.CreateUser(ctx, request.SystemAuth, record)
+			if err != nil {
+				return s.failItem(run, item, fmt.Errorf("创建用户失败: %w", err))
+			}
+			item.UserID = created.User.ID
+		}
+		run.Created++
+		return item
+	}
+
+	changes := diffUser(existing.User.UserBase, record.UserBase)
+	if len(changes) == 0 {
+		run.Skipped++
+		return nil
+	}
+	item.Action = models.UserSyncActionUpdate
+	item.UserID = existing.User.ID
+	item.Changes = marshalChanges(changes)
+	if !dryRun {
+		if _, err := s.client.UpdateUser(ctx, request.SystemAuth, existing.User.ID, &request.UpdateUserRequest{UserBase: record.UserBase}); err != nil {
+			return s.failItem(run, item, fmt.Errorf("更新用户失败: %w", err))
+		}
+	}
+	run.Updated++
+	return item
+}
+
+// failItem 将明细标记为失败并写日志，保留已计算的字段差异
+func (s *Syncer) failItem(run *models.UserSyncRun, item *models.UserSyncItem, err error) *models.UserSyncItem {
+	item.Action = models.UserSyncActionFailed
+	item.Error = err.Error()
+	run.Failed++
+	s.writer.Error("同步用户失败",
+		writer.Field("log_type", "system"),
+		writer.Field("trace", "UserSync.syncOne"),
+		writer.Field("username", "system"),
+		writer.Field("sync_run_id", run.ID),
+		writer.Field("login_name", item.LoginName),
+		writer.Field("error", err.Error()),
+	)
+	return item
+}
+
+func (s *Syncer) logDBError(trace, source string, err error) {
+	s.writer.Error("写入用户同步记录失败",
+		writer.Field("log_type", "database"),
+		writer.Field("trace", trace),
+		writer.Field("username", "system"),
+		writer.Field("source", source),
+		writer.Field("error", err.Error()),
+	)
+}
+
+func marshalChanges(changes map[string]Change) string {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
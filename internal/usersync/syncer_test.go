@@ -0,0 +1,121 @@
+package usersync
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/mock/userservice"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/request"
+	"go-zero-template/internal/types"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// newTestSyncer 内嵌启动模拟 user_service，已有用户 alice，系统账号为 admin
+func newTestSyncer(t *testing.T) (*Syncer, *userservice.Server) {
+	t.Helper()
+	srv := userservice.New(&userservice.Fixture{
+		Users: []types.User{
+			{UserBase: types.UserBase{LoginName: "admin", Name: "管理员", Status: 1}},
+			{UserBase: types.UserBase{LoginName: "alice", Name: "Alice", Status: 1}},
+		},
+		Passwords: map[string]string{"admin": "secret"},
+	})
+	ts := srv.Start()
+	t.Cleanup(ts.Close)
+
+	c := srv.ServiceConfig(ts.URL)
+	c.SuperAdminUsername = "admin"
+	c.SuperAdminPassword = "secret"
+	w := writer.NewMultiWriter(writer.NewConsoleWriter())
+	client := request.NewRegistry(config.ServicesConfig{config.UserServiceName: c}, w).UserService()
+	return NewSyncer(client, nil, w), srv
+}
+
+func TestSyncOne(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("用户不存在时创建", func(t *testing.T) {
+		s, srv := newTestSyncer(t)
+		run := &models.UserSyncRun{}
+		record := &Record{UserBase: types.UserBase{LoginName: "bob", Name: "Bob", Status: 1}}
+
+		item := s.syncOne(ctx, run, record, false)
+		if item == nil || item.Action != models.UserSyncActionCreate {
+			t.Fatalf("item = %+v, want create", item)
+		}
+		if run.Created != 1 || run.Failed != 0 {
+			t.Fatalf("created = %d, failed = %d", run.Created, run.Failed)
+		}
+		created, ok := srv.User(item.UserID)
+		if !ok || created.LoginName != "bob" || created.Name != "Bob" {
+			t.Fatalf("模拟服务中的用户 = %+v, ok = %v", created, ok)
+		}
+	})
+
+	t.Run("dry-run 不创建用户", func(t *testing.T) {
+		s, srv := newTestSyncer(t)
+		run := &models.UserSyncRun{}
+		item := s.syncOne(ctx, run, &Record{UserBase: types.UserBase{LoginName: "bob", Name: "Bob"}}, true)
+		if item == nil || item.Action != models.UserSyncActionCreate || run.Created != 1 {
+			t.Fatalf("item = %+v, created = %d", item, run.Created)
+		}
+		if calls := srv.Calls(userservice.EndpointCreate); calls != 0 {
+			t.Fatalf("dry-run 调用创建接口 %d 次", calls)
+		}
+	})
+
+	t.Run("用户存在且有差异时更新", func(t *testing.T) {
+		s, srv := newTestSyncer(t)
+		run := &models.UserSyncRun{}
+		item := s.syncOne(ctx, run, &Record{UserBase: types.UserBase{LoginName: "alice", Name: "Alice Wang", Status: 1}}, false)
+		if item == nil || item.Action != models.UserSyncActionUpdate || run.Updated != 1 {
+			t.Fatalf("item = %+v, updated = %d", item, run.Updated)
+		}
+		if updated, _ := srv.User(item.UserID); updated.Name != "Alice Wang" {
+			t.Fatalf("更新后姓名 = %q", updated.Name)
+		}
+	})
+
+	t.Run("用户存在且无差异时跳过", func(t *testing.T) {
+		s, _ := newTestSyncer(t)
+		run := &models.UserSyncRun{}
+		if item := s.syncOne(ctx, run, &Record{UserBase: types.UserBase{LoginName: "alice", Name: "Alice", Status: 1}}, false); item != nil {
+			t.Fatalf("item = %+v, want nil", item)
+		}
+		if run.Skipped != 1 {
+			t.Fatalf("skipped = %d", run.Skipped)
+		}
+	})
+
+	t.Run("查询返回 500 时记为失败", func(t *testing.T) {
+		s, srv := newTestSyncer(t)
+		srv.SetFault(userservice.EndpointGetByLoginName, userservice.Fault{Status: http.StatusInternalServerError, Times: 1})
+		run := &models.UserSyncRun{}
+
+		item := s.syncOne(ctx, run, &Record{UserBase: types.UserBase{LoginName: "bob", Name: "Bob"}}, false)
+		if item == nil || item.Action != models.UserSyncActionFailed || item.Error == "" {
+			t.Fatalf("item = %+v, want failed", item)
+		}
+		if run.Failed != 1 || run.Created != 0 {
+			t.Fatalf("failed = %d, created = %d", run.Failed, run.Created)
+		}
+		if calls := srv.Calls(userservice.EndpointCreate); calls != 0 {
+			t.Fatalf("查询失败后仍调用创建接口 %d 次", calls)
+		}
+	})
+
+	t.Run("创建返回 500 时记为失败", func(t *testing.T) {
+		s, srv := newTestSyncer(t)
+		srv.SetFault(userservice.EndpointCreate, userservice.Fault{Status: http.StatusInternalServerError})
+		run := &models.UserSyncRun{}
+
+		item := s.syncOne(ctx, run, &Record{UserBase: types.UserBase{LoginName: "bob", Name: "Bob"}}, false)
+		if item == nil || item.Action != models.UserSyncActionFailed || run.Failed != 1 {
+			t.Fatalf("item = %+v, failed = %d", item, run.Failed)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	instanceID     string
+	instanceIDOnce sync.Once
+)
+
+// InstanceID 当前实例标识（hostname-pid），用于分布式锁 owner 和运行记录
+func InstanceID() string {
+	instanceIDOnce.Do(func() {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "unknown"
+		}
+		instanceID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	})
+	return instanceID
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TokenTypeAccess 访问 token 类型，refresh token 不能用于访问接口
+const TokenTypeAccess = "access"
+
+var (
+	// ErrEmptySecret 未配置 JWT 签名密钥
+	ErrEmptySecret = errors.New("未配置 JWT 签名密钥")
+	// ErrInvalidTokenType token 类型不是 access
+	ErrInvalidTokenType = errors.New("token 类型无效")
+	// ErrMissingExpiry token 未设置过期时间（exp）
+	ErrMissingExpiry = errors.New("token 缺少过期时间")
+)
+
+// tokenClaims JWT claims：TokenPayload 字段与标准声明（exp、iat 等）位于同一层
+type tokenClaims struct {
+	TokenPayload
+	jwt.RegisteredClaims
+}
+
+// ParseAccessToken 使用 secret 校验 token 的 HMAC 签名和有效期，解析为 TokenPayload
+// tokenString 不含 "Bearer " 前缀，未设置 exp 时返回 ErrMissingExpiry，token 类型不是 access 时返回 ErrInvalidTokenType
+func ParseAccessToken(tokenString, secret string) (*TokenPayload, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("不支持的签名算法: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// jwt 库只在 exp 存在时校验有效期，永不过期的 token 一旦泄露无法失效
+	if claims.ExpiresAt == nil {
+		return nil, ErrMissingExpiry
+	}
+	if claims.Type != TokenTypeAccess {
+		return nil, ErrInvalidTokenType
+	}
+	return &claims.TokenPayload, nil
+}
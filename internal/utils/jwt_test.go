@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, method jwt.SigningMethod, secret any, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(secret)
+	if err != nil {
+		t.Fatalf("签发 token 失败: %v", err)
+	}
+	return token
+}
+
+func accessClaims(tokenType string, expiresAt *jwt.NumericDate) tokenClaims {
+	return tokenClaims{
+		TokenPayload:     TokenPayload{UserID: 42, Type: tokenType},
+		RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(time.Now()), ExpiresAt: expiresAt},
+	}
+}
+
+func TestParseAccessToken(t *testing.T) {
+	valid := jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	payload, err := ParseAccessToken(signToken(t, jwt.SigningMethodHS256, []byte(testSecret), accessClaims(TokenTypeAccess, valid)), testSecret)
+	if err != nil {
+		t.Fatalf("有效 token 解析失败: %v", err)
+	}
+	if payload.UserID != 42 || payload.Type != TokenTypeAccess {
+		t.Fatalf("payload = %+v", payload)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		secret  string
+		wantErr error // 为 nil 时只要求返回错误
+	}{
+		{"未配置密钥", signToken(t, jwt.SigningMethodHS256, []byte(testSecret), accessClaims(TokenTypeAccess, valid)), "", ErrEmptySecret},
+		{"缺少 exp", signToken(t, jwt.SigningMethodHS256, []byte(testSecret), accessClaims(TokenTypeAccess, nil)), testSecret, ErrMissingExpiry},
+		{"已过期", signToken(t, jwt.SigningMethodHS256, []byte(testSecret), accessClaims(TokenTypeAccess, jwt.NewNumericDate(time.Now().Add(-time.Minute)))), testSecret, nil},
+		{"refresh token", signToken(t, jwt.SigningMethodHS256, []byte(testSecret), accessClaims("refresh", valid)), testSecret, ErrInvalidTokenType},
+		{"签名密钥不一致", signToken(t, jwt.SigningMethodHS256, []byte("other-secret"), accessClaims(TokenTypeAccess, valid)), testSecret, nil},
+		{"不支持的签名算法", signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, accessClaims(TokenTypeAccess, valid)), testSecret, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := ParseAccessToken(tt.token, tt.secret)
+			if err == nil {
+				t.Fatalf("ParseAccessToken() = %+v, want error", payload)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseAccessToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package utils
+
+import "slices"
+
+// RoleType 用户角色，对应 types.User.RoleCode
+type RoleType string
+
+const (
+	RoleSuperAdmin    RoleType = "super_admin"    // 超级管理员
+	RoleAdmin         RoleType = "admin"          // 管理员
+	RoleSuperReviewer RoleType = "super_reviewer" // 超级审核员
+	RoleOperator      RoleType = "operator"       // 操作员
+	RoleRegionManager RoleType = "region_manager" // 区域管理员
+)
+
+var (
+	// AdminGroup 系统管理员：管理定时任务、工作流、用户同步及认证缓存
+	AdminGroup = []RoleType{RoleSuperAdmin, RoleAdmin}
+	// ManagerGroup 管理类角色，业务接口默认使用的权限组
+	ManagerGroup = []RoleType{RoleSuperAdmin, RoleAdmin, RoleRegionManager}
+	// AllRoles 所有角色
+	AllRoles = []RoleType{RoleSuperAdmin, RoleAdmin, RoleSuperReviewer, RoleOperator, RoleRegionManager}
+)
+
+// RouteGuard 判断角色是否属于权限组
+func RouteGuard(roleCode string, group []RoleType) bool {
+	return slices.Contains(group, RoleType(roleCode))
+}
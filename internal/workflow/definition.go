@@ -0,0 +1,87 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Node 工作流节点，Handler 为调度器中注册的处理器名称
+type Node struct {
+	Name      string
+	Handler   string
+	DependsOn []string
+	// Timeout 可选，节点执行超时时间，0 表示不限制
+	Timeout time.Duration
+}
+
+// Definition 工作流定义：节点按 DependsOn 组成有向无环图，无依赖的节点并发执行，
+// 节点在所有上游成功后执行，任一上游未成功时跳过
+type Definition struct {
+	Name        string
+	Description string
+	Nodes       []Node
+}
+
+// Validate 校验节点名称唯一、依赖存在且不成环
+func (d Definition) Validate() error {
+	if d.Name == "" {
+		return errors.New("工作流名称不能为空")
+	}
+	if len(d.Nodes) == 0 {
+		return fmt.Errorf("工作流 %s 没有节点", d.Name)
+	}
+
+	nodes := make(map[string]Node, len(d.Nodes))
+	for _, node := range d.Nodes {
+		if node.Name == "" || node.Handler == "" {
+			return fmt.Errorf("工作流 %s 的节点名称和处理器不能为空", d.Name)
+		}
+		if _, ok := nodes[node.Name]; ok {
+			return fmt.Errorf("工作流 %s 的节点重名: %s", d.Name, node.Name)
+		}
+		nodes[node.Name] = node
+	}
+
+	// Kahn 拓扑排序，剩余未排序的节点即在环上
+	indegree := make(map[string]int, len(nodes))
+	downstream := make(map[string][]string, len(nodes))
+	for _, node := range d.Nodes {
+		for _, dep := range node.DependsOn {
+			if _, ok := nodes[dep]; !ok {
+				return fmt.Errorf("工作流 %s 的节点 %s 依赖不存在的节点 %s", d.Name, node.Name, dep)
+			}
+			indegree[node.Name]++
+			downstream[dep] = append(downstream[dep], node.Name)
+		}
+	}
+	var queue []string
+	for _, node := range d.Nodes {
+		if indegree[node.Name] == 0 {
+			queue = append(queue, node.Name)
+		}
+	}
+	sorted := 0
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		sorted++
+		for _, next := range downstream[name] {
+			indegree[next]--
+			if indegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+	if sorted != len(nodes) {
+		var cyclic []string
+		for _, node := range d.Nodes {
+			if indegree[node.Name] > 0 {
+				cyclic = append(cyclic, node.Name)
+			}
+		}
+		return fmt.Errorf("工作流 %s 存在循环依赖: %s", d.Name, strings.Join(cyclic, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefinitionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     Definition
+		wantErr string
+	}{
+		{
+			"链式依赖",
+			Definition{Name: "nightly", Nodes: []Node{
+				{Name: "org", Handler: "OrgSync"},
+				{Name: "user", Handler: "UserSync", DependsOn: []string{"org"}},
+				{Name: "report", Handler: "UserReport", DependsOn: []string{"user"}},
+			}},
+			"",
+		},
+		{
+			"扇出扇入",
+			Definition{Name: "diamond", Nodes: []Node{
+				{Name: "d", Handler: "h", DependsOn: []string{"b", "c"}},
+				{Name: "b", Handler: "h", DependsOn: []string{"a"}},
+				{Name: "c", Handler: "h", DependsOn: []string{"a"}},
+				{Name: "a", Handler: "h"},
+			}},
+			"",
+		},
+		{"名称为空", Definition{Nodes: []Node{{Name: "a", Handler: "h"}}}, "工作流名称不能为空"},
+		{"没有节点", Definition{Name: "empty"}, "没有节点"},
+		{"节点处理器为空", Definition{Name: "w", Nodes: []Node{{Name: "a"}}}, "节点名称和处理器不能为空"},
+		{
+			"节点重名",
+			Definition{Name: "w", Nodes: []Node{{Name: "a", Handler: "h"}, {Name: "a", Handler: "h2"}}},
+			"节点重名: a",
+		},
+		{
+			"依赖不存在",
+			Definition{Name: "w", Nodes: []Node{{Name: "a", Handler: "h", DependsOn: []string{"missing"}}}},
+			"依赖不存在的节点 missing",
+		},
+		{
+			"自依赖",
+			Definition{Name: "w", Nodes: []Node{{Name: "a", Handler: "h", DependsOn: []string{"a"}}}},
+			"存在循环依赖: a",
+		},
+		{
+			"环上的节点及其下游",
+			Definition{Name: "w", Nodes: []Node{
+				{Name: "root", Handler: "h"},
+				{Name: "a", Handler: "h", DependsOn: []string{"root", "c"}},
+				{Name: "b", Handler: "h", DependsOn: []string{"a"}},
+				{Name: "c", Handler: "h", DependsOn: []string{"b"}},
+				{Name: "tail", Handler: "h", DependsOn: []string{"c"}},
+			}},
+			"存在循环依赖: a, b, c, tail",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.def.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate 错误 = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate 错误 = %v, want 包含 %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-zero-template/internal/db"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/scheduler"
+	"go-zero-template/internal/utils"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// HandlerPrefix 工作流注册到调度器的处理器名称前缀
+const HandlerPrefix = "workflow:"
+
+// HandlerName 工作流注册到调度器的处理器名称，cron_tasks.handler 填写该名称即可定时运行工作流
+func HandlerName(workflow string) string {
+	return HandlerPrefix + workflow
+}
+
+// Runner 工作流执行器：节点处理器从调度器中按名称查找，工作流和节点运行记录写入数据库
+type Runner struct {
+	repo   *db.Repository
+	writer *writer.MultiWriter
+	sched  *scheduler.Scheduler
+
+	mu   sync.RWMutex
+	defs map[string]Definition
+}
+
+func NewRunner(repo *db.Repository, w *writer.MultiWriter, sched *scheduler.Scheduler) *Runner {
+	return &Runner{
+		repo:   repo,
+		writer: w,
+		sched:  sched,
+		defs:   make(map[string]Definition),
+	}
+}
+
+// Register 注册工作流，并以 HandlerName(名称) 注册为调度器处理器，定义不合法时返回错误
+func (r *Runner) Register(defs ...Definition) error {
+	for _, def := range defs {
+		if err := def.Validate(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, def := range defs {
+		name := def.Name
+		r.defs[name] = def
+		r.sched.AddHandlers(scheduler.Route{
+			Name: HandlerName(name),
+			Handler: func(ctx context.Context) error {
+				return r.Run(ctx, name)
+			},
+		})
+	}
+	return nil
+}
+
+// MustRegister 注册工作流，定义不合法时 panic
+func (r *Runner) MustRegister(defs ...Definition) {
+	if err := r.Register(defs...); err != nil {
+		panic(err)
+	}
+}
+
+// Definitions 已注册的工作流，按名称排序
+func (r *Runner) Definitions() []Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	defs := make([]Definition, 0, len(r.defs))
+	for _, def := range r.defs {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+	return defs
+}
+
+// Run 执行一次工作流并等待所有节点结束，任一节点未成功时返回错误
+func (r *Runner) Run(ctx context.Context, name string) error {
+	r.mu.RLock()
+	def, ok := r.defs[name]
+	r.mu.RUnlock()
+	if !ok {
+		return scheduler.Permanent(fmt.Errorf("工作流未注册: %s", name))
+	}
+
+	run := &models.WorkflowRun{
+		Workflow:  name,
+		Status:    models.RunStatusRunning,
+		StartedAt: time.Now(),
+		Instance:  utils.InstanceID(),
+	}
+	if err := r.repo.WorkflowRun.Create(context.Background(), run); err != nil {
+		r.logDBError("WorkflowRun.Create", name, err)
+		return err
+	}
+
+	records := make(map[string]*models.WorkflowNodeRun, len(def.Nodes))
+	batch := make([]*models.WorkflowNodeRun, 0, len(def.Nodes))
+	for _, node := range def.Nodes {
+		record := &models.WorkflowNodeRun{
+			WorkflowRunID: run.ID,
+			Node:          node.Name,
+			Handler:       node.Handler,
+			DependsOn:     strings.Join(node.DependsOn, ","),
+			Status:        models.NodeStatusPending,
+		}
+		records[node.Name] = record
+		batch = append(batch, record)
+	}
+	if err := r.repo.WorkflowNodeRun.CreateBatch(context.Background(), batch); err != nil {
+		r.logDBError("WorkflowNodeRun.CreateBatch", name, err)
+	}
+
+	r.execute(ctx, def, records)
+
+	var unsuccessful []string
+	for _, node := range def.Nodes {
+		if status := records[node.Name].Status; status != models.RunStatusSuccess {
+			unsuccessful = append(unsuccessful, fmt.Sprintf("%s(%s)", node.Name, status))
+		}
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.DurationMs = finishedAt.Sub(run.StartedAt).Milliseconds()
+	run.Status = models.RunStatusSuccess
+	var runErr error
+	if len(unsuccessful) > 0 {
+		run.Status = models.RunStatusFailed
+		run.Error = "节点未成功: " + strings.Join(unsuccessful, ", ")
+		runErr = errors.New(run.Error)
+	}
+	if err := r.repo.WorkflowRun.Finish(context.Background(), run); err != nil {
+		r.logDBError("WorkflowRun.Finish", name, err)
+	}
+	return runErr
+}
+
+// execute 按依赖关系并发执行所有节点并等待结束，节点状态写入 records
+func (r *Runner) execute(ctx context.Context, def Definition, records map[string]*models.WorkflowNodeRun) {
+	// 每个节点结束时关闭对应 channel，下游节点等待所有上游 channel 关闭后再读取上游状态
+	done := make(map[string]chan struct{}, len(def.Nodes))
+	for _, node := range def.Nodes {
+		done[node.Name] = make(chan struct{})
+	}
+	var wg sync.WaitGroup
+	for _, node := range def.Nodes {
+		wg.Add(1)
+		go func(node Node) {
+			defer wg.Done()
+			defer close(done[node.Name])
+			for _, dep := range node.DependsOn {
+				<-done[dep]
+			}
+			r.runNode(ctx, def.Name, node, records)
+		}(node)
+	}
+	wg.Wait()
+}
+
+// runNode 执行单个节点：任一上游未成功时跳过，工作流已取消时标记为取消
+func (r *Runner) runNode(ctx context.Context, workflow string, node Node, records map[string]*models.WorkflowNodeRun) {
+	record := records[node.Name]
+
+	for _, dep := range node.DependsOn {
+		if records[dep].Status != models.RunStatusSuccess {
+			r.finishNode(workflow, record, models.RunStatusSkipped, fmt.Sprintf("上游节点 %s 未成功", dep))
+			return
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		r.finishNode(workflow, record, models.RunStatusCanceled, err.Error())
+		return
+	}
+	route, ok := r.sched.Lookup(node.Handler)
+	if !ok {
+		r.finishNode(workflow, record, models.RunStatusFailed, fmt.Sprintf("处理器未注册: %s", node.Handler))
+		return
+	}
+
+	startedAt := time.Now()
+	record.StartedAt = &startedAt
+	record.Status = models.RunStatusRunning
+	r.updateNode(workflow, record)
+
+	nodeCtx := ctx
+	if node.Timeout > 0 {
+		var cancel context.CancelFunc
+		nodeCtx, cancel = context.WithTimeout(ctx, node.Timeout)
+		defer cancel()
+	}
+	err := safeCall(nodeCtx, route.Handler)
+	switch {
+	case err == nil:
+		r.finishNode(workflow, record, models.RunStatusSuccess, "")
+	case errors.Is(err, context.DeadlineExceeded):
+		r.finishNode(workflow, record, models.RunStatusTimeout, err.Error())
+	case ctx.Err() != nil:
+		r.finishNode(workflow, record, models.RunStatusCanceled, err.Error())
+	default:
+		r.finishNode(workflow, record, models.RunStatusFailed, err.Error())
+	}
+}
+
+// finishNode 更新节点结束状态，未成功时写日志
+func (r *Runner) finishNode(workflow string, record *models.WorkflowNodeRun, status, errMsg string) {
+	finishedAt := time.Now()
+	record.FinishedAt = &finishedAt
+	if record.StartedAt != nil {
+		record.DurationMs = finishedAt.Sub(*record.StartedAt).Milliseconds()
+	}
+	record.Status = status
+	record.Error = errMsg
+	r.updateNode(workflow, record)
+
+	if status == models.RunStatusSuccess {
+		return
+	}
+	r.writer.Error("工作流节点未成功",
+		writer.Field("log_type", "system"),
+		writer.Field("trace", "Workflow."+workflow),
+		writer.Field("username", "system"),
+		writer.Field("workflow_run_id", record.WorkflowRunID),
+		writer.Field("node", record.Node),
+		writer.Field("status", status),
+		writer.Field("error", errMsg),
+	)
+}
+
+// updateNode 将节点状态写回节点运行记录，批量写入节点运行记录失败（ID 为 0）时跳过
+func (r *Runner) updateNode(workflow string, record *models.WorkflowNodeRun) {
+	if record.ID == 0 {
+		return
+	}
+	if err := r.repo.WorkflowNodeRun.Update(context.Background(), record); err != nil {
+		r.logDBError("WorkflowNodeRun.Update", workflow, err)
+	}
+}
+
+func (r *Runner) logDBError(trace, workflow string, err error) {
+	r.writer.Error("写入工作流运行记录失败",
+		writer.Field("log_type", "database"),
+		writer.Field("trace", trace),
+		writer.Field("username", "system"),
+		writer.Field("workflow", workflow),
+		writer.Field("error", err.Error()),
+	)
+}
+
+// safeCall 执行处理器并将 panic 转为 error
+func safeCall(ctx context.Context, handler scheduler.Handler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return handler(ctx)
+}
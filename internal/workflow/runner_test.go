@@ -0,0 +1,168 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go-zero-template/internal/config"
+	"go-zero-template/internal/models"
+	"go-zero-template/internal/scheduler"
+
+	writer "github.com/zhengliu92/pg-log-writter"
+)
+
+// recorder 记录节点处理器的开始和结束顺序
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) index(event string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Index(r.events, event)
+}
+
+// stub 返回记录开始、结束事件的处理器，delay 后返回 err
+func (r *recorder) stub(name string, delay time.Duration, err error) scheduler.Route {
+	return scheduler.Route{Name: name, Handler: func(ctx context.Context) error {
+		r.add("start:" + name)
+		defer r.add("end:" + name)
+		select {
+		case <-time.After(delay):
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}}
+}
+
+// newTestRunner 不写数据库的 Runner：节点运行记录 ID 为 0 时不更新
+func newTestRunner(routes ...scheduler.Route) *Runner {
+	sched := scheduler.NewScheduler(config.SchedulerConfig{}, nil, nil, nil)
+	sched.AddHandlers(routes...)
+	return NewRunner(nil, writer.NewMultiWriter(writer.NewConsoleWriter()), sched)
+}
+
+func newRecords(def Definition) map[string]*models.WorkflowNodeRun {
+	records := make(map[string]*models.WorkflowNodeRun, len(def.Nodes))
+	for _, node := range def.Nodes {
+		records[node.Name] = &models.WorkflowNodeRun{Node: node.Name, Handler: node.Handler, Status: models.NodeStatusPending}
+	}
+	return records
+}
+
+func TestRunnerFanIn(t *testing.T) {
+	rec := &recorder{}
+	r := newTestRunner(
+		rec.stub("a", 0, nil),
+		rec.stub("b", 30*time.Millisecond, nil),
+		rec.stub("c", 10*time.Millisecond, nil),
+		rec.stub("d", 0, nil),
+	)
+	def := Definition{Name: "diamond", Nodes: []Node{
+		{Name: "a", Handler: "a"},
+		{Name: "b", Handler: "b", DependsOn: []string{"a"}},
+		{Name: "c", Handler: "c", DependsOn: []string{"a"}},
+		{Name: "d", Handler: "d", DependsOn: []string{"b", "c"}},
+	}}
+	records := newRecords(def)
+	r.execute(context.Background(), def, records)
+
+	for _, node := range def.Nodes {
+		if status := records[node.Name].Status; status != models.RunStatusSuccess {
+			t.Fatalf("节点 %s 状态 = %q, want success", node.Name, status)
+		}
+	}
+	// b、c 在 a 结束后开始，d 在 b、c 都结束后开始
+	for _, order := range [][2]string{
+		{"end:a", "start:b"},
+		{"end:a", "start:c"},
+		{"end:b", "start:d"},
+		{"end:c", "start:d"},
+	} {
+		if rec.index(order[0]) > rec.index(order[1]) {
+			t.Fatalf("%s 应早于 %s，实际顺序 %v", order[0], order[1], rec.events)
+		}
+	}
+	// b、c 并发执行：c 先于较慢的 b 结束
+	if rec.index("start:b") > rec.index("end:c") {
+		t.Fatalf("b、c 未并发执行，实际顺序 %v", rec.events)
+	}
+}
+
+func TestRunnerSkipAfterFailure(t *testing.T) {
+	rec := &recorder{}
+	r := newTestRunner(
+		rec.stub("org", 0, errors.New("上游不可用")),
+		rec.stub("user", 0, nil),
+		rec.stub("report", 0, nil),
+		rec.stub("audit", 0, nil),
+	)
+	def := Definition{Name: "nightly", Nodes: []Node{
+		{Name: "org", Handler: "org"},
+		{Name: "user", Handler: "user", DependsOn: []string{"org"}},
+		{Name: "report", Handler: "report", DependsOn: []string{"user"}},
+		{Name: "audit", Handler: "audit"},
+		{Name: "missing", Handler: "missing"},
+		{Name: "after_missing", Handler: "audit", DependsOn: []string{"missing", "audit"}},
+	}}
+	records := newRecords(def)
+	r.execute(context.Background(), def, records)
+
+	want := map[string]string{
+		"org":           models.RunStatusFailed,
+		"user":          models.RunStatusSkipped,
+		"report":        models.RunStatusSkipped,
+		"audit":         models.RunStatusSuccess,
+		"missing":       models.RunStatusFailed,
+		"after_missing": models.RunStatusSkipped,
+	}
+	for node, status := range want {
+		if got := records[node].Status; got != status {
+			t.Errorf("节点 %s 状态 = %q, want %q", node, got, status)
+		}
+	}
+	if got := records["report"].Error; got != "上游节点 user 未成功" {
+		t.Errorf("report 跳过原因 = %q", got)
+	}
+	// 被跳过的节点不执行处理器
+	if rec.index("start:user") >= 0 || rec.index("start:report") >= 0 {
+		t.Fatalf("跳过的节点执行了处理器: %v", rec.events)
+	}
+}
+
+func TestRunnerNodeTimeoutAndCancel(t *testing.T) {
+	rec := &recorder{}
+	r := newTestRunner(rec.stub("slow", time.Second, nil), rec.stub("next", 0, nil))
+	def := Definition{Name: "timeout", Nodes: []Node{
+		{Name: "slow", Handler: "slow", Timeout: 10 * time.Millisecond},
+		{Name: "next", Handler: "next", DependsOn: []string{"slow"}},
+	}}
+	records := newRecords(def)
+	r.execute(context.Background(), def, records)
+	if got := records["slow"].Status; got != models.RunStatusTimeout {
+		t.Fatalf("slow 状态 = %q, want timeout", got)
+	}
+	if got := records["next"].Status; got != models.RunStatusSkipped {
+		t.Fatalf("next 状态 = %q, want skipped", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	records = newRecords(def)
+	r.execute(ctx, def, records)
+	if got := records["slow"].Status; got != models.RunStatusCanceled {
+		t.Fatalf("取消后 slow 状态 = %q, want canceled", got)
+	}
+}